@@ -1,6 +1,9 @@
 package golsptoolkit
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 // HeaderPart represents the parsed LSP message header.
 //
@@ -12,6 +15,16 @@ import "encoding/json"
 type HeaderPart struct {
 	ContentLength int
 	ContentType   string
+
+	// MediaType and Charset are parsed from ContentType, or from
+	// DefaultContentType when the header is absent. Charset is normalized
+	// to "utf-8".
+	MediaType string
+	Charset   string
+
+	// Extra holds header fields other than Content-Length and Content-Type,
+	// keyed by canonical name. It is only populated in HeaderModeLenient.
+	Extra map[string]string
 }
 
 type ContentPart struct {
@@ -22,17 +35,44 @@ type ContentPart struct {
 // See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#baseTypes
 type (
 	Integer   = int32
-	UInteger  = uint32
+	UInteger  uint32
 	Decimal   = float64
 	LSPAny    = any
 	LSPObject = map[string]LSPAny
 	LSPArray  = []LSPAny
 )
 
-// IsLSPAny checks if the given value is a valid LSPAny type.
+// MaxUInteger is the largest UInteger the protocol allows, 2^31 - 1.
+const MaxUInteger UInteger = 1<<31 - 1
+
+// UnmarshalJSON implements json.Unmarshaler. Values outside the range 0 to
+// MaxUInteger are rejected rather than wrapped or truncated, so malformed
+// positions and lengths are caught when a message is decoded.
+func (u *UInteger) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	var n int64
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("golsptoolkit: invalid uinteger %s", data)
+	}
+	if n < 0 || n > int64(MaxUInteger) {
+		return fmt.Errorf("golsptoolkit: uinteger %d is out of range [0, %d]", n, MaxUInteger)
+	}
+	*u = UInteger(n)
+	return nil
+}
+
+// ClampUInteger returns n clamped to the range 0 to MaxUInteger.
+func ClampUInteger(n int) UInteger {
+	return UInteger(max(0, min(int64(n), int64(MaxUInteger))))
+}
+
+// IsLSPAny checks if the given value is a valid LSPAny type. Plain uint32
+// values are accepted as well as UInteger.
 func IsLSPAny(v any) bool {
 	switch v.(type) {
-	case string, Integer, UInteger, Decimal, bool, nil, LSPObject, LSPArray:
+	case string, Integer, UInteger, uint32, Decimal, bool, nil, LSPObject, LSPArray:
 		return true
 	default:
 		return false
@@ -49,6 +89,9 @@ func IsLSPArrayOrObject(v any) bool {
 	}
 }
 
+// JSONRPCVersion is the value of the jsonrpc field of every LSP message.
+const JSONRPCVersion = "2.0"
+
 // Abstract Message represents a base message structure in the Language Server Protocol.
 //
 // See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#abstractMessage
@@ -61,9 +104,9 @@ type AbstractMessage struct {
 // See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#requestMessage
 type RequestMessage struct {
 	AbstractMessage
-	ID     json.Number `json:"id"`
-	Method string      `json:"method"`
-	Params LSPAny      `json:"params,omitempty"`
+	ID     ID     `json:"id"`
+	Method string `json:"method"`
+	Params LSPAny `json:"params,omitempty"`
 }
 
 // Response Message represents a response message structure in the Language Server Protocol.
@@ -71,18 +114,48 @@ type RequestMessage struct {
 // See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#responseMessage
 type ResponseMessage struct {
 	AbstractMessage
-	ID     *json.Number   `json:"id"`
+	ID     *ID            `json:"id"`
 	Result LSPAny         `json:"result,omitempty"`
 	Error  *ResponseError `json:"error,omitempty"`
 }
 
+// MarshalJSON implements json.Marshaler. A successful response always
+// carries the result member, even when it is null, while an error response
+// never does.
+func (m ResponseMessage) MarshalJSON() ([]byte, error) {
+	if m.Error != nil {
+		return json.Marshal(struct {
+			AbstractMessage
+			ID    *ID            `json:"id"`
+			Error *ResponseError `json:"error"`
+		}{m.AbstractMessage, m.ID, m.Error})
+	}
+	return json.Marshal(struct {
+		AbstractMessage
+		ID     *ID    `json:"id"`
+		Result LSPAny `json:"result"`
+	}{m.AbstractMessage, m.ID, m.Result})
+}
+
 // Response Error represents an error response structure in the Language Server Protocol.
 //
 // See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#responseError
 type ResponseError struct {
 	Code    Integer `json:"code"`
 	Message string  `json:"message"`
-	Data    LSPAny  `json:"data,omitempty"`
+
+	// Data holds additional information about the error. In errors
+	// received from the peer it is the undecoded json.RawMessage; ErrData
+	// decodes it.
+	Data LSPAny `json:"data,omitempty"`
+
+	// cause is the error wrapped by WrapError. It is not sent to the peer.
+	cause error
+}
+
+// Error implements the error interface.
+func (e *ResponseError) Error() string {
+	return e.Message
 }
 
 // Notification Message represents a notification message structure in the Language Server Protocol.
@@ -124,11 +197,24 @@ const (
 	LSPReservedErrorRangeEnd   Integer = -32800
 )
 
+// Methods of the base protocol.
+const (
+	MethodCancelRequest = "$/cancelRequest"
+	MethodProgress      = "$/progress"
+)
+
+// CancelParams are the parameters of the $/cancelRequest notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#cancelRequest
 type CancelParams struct {
-	ID json.Number `json:"id"`
+	// ID is the id of the request to cancel.
+	ID ID `json:"id"`
 }
 
+// ProgressParams are the parameters of the $/progress notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#progress
 type ProgressParams[T any] struct {
-	Token json.Number `json:"token"`
-	Value T           `json:"value"`
+	Token ProgressToken `json:"token"`
+	Value T             `json:"value"`
 }
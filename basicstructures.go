@@ -0,0 +1,247 @@
+package golsptoolkit
+
+// URI represents a URI, such as the target of a link, transferred as a
+// string.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#uri
+type URI = string
+
+// DocumentURI represents the URI of a document, such as
+// "file:///home/user/project/main.go".
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentUri
+type DocumentURI = string
+
+// Position represents a position in a text document as a zero-based line
+// and a zero-based character offset within the line. How the offset is
+// counted depends on the negotiated position encoding, UTF-16 code units by
+// default.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#position
+type Position struct {
+	Line      UInteger `json:"line"`
+	Character UInteger `json:"character"`
+}
+
+// NewPosition returns the position at line and character.
+func NewPosition(line, character UInteger) Position {
+	return Position{Line: line, Character: character}
+}
+
+// Compare returns -1 if p is before q, 1 if p is after q, and 0 if they are
+// equal.
+func (p Position) Compare(q Position) int {
+	switch {
+	case p.Line < q.Line:
+		return -1
+	case p.Line > q.Line:
+		return 1
+	case p.Character < q.Character:
+		return -1
+	case p.Character > q.Character:
+		return 1
+	}
+	return 0
+}
+
+// Before reports whether p is before q.
+func (p Position) Before(q Position) bool {
+	return p.Compare(q) < 0
+}
+
+// After reports whether p is after q.
+func (p Position) After(q Position) bool {
+	return p.Compare(q) > 0
+}
+
+// Range represents a range in a text document. End is exclusive.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#range
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// NewRange returns the range from start to end.
+func NewRange(start, end Position) Range {
+	return Range{Start: start, End: end}
+}
+
+// IsEmpty reports whether r contains no characters, that is whether its end
+// is not after its start.
+func (r Range) IsEmpty() bool {
+	return !r.End.After(r.Start)
+}
+
+// Contains reports whether p lies within r. Since End is exclusive, a
+// position equal to End is not contained, and an empty range contains
+// nothing.
+func (r Range) Contains(p Position) bool {
+	return !p.Before(r.Start) && p.Before(r.End)
+}
+
+// ContainsRange reports whether o lies entirely within r.
+func (r Range) ContainsRange(o Range) bool {
+	return !o.Start.Before(r.Start) && !o.End.After(r.End)
+}
+
+// Overlaps reports whether r and o share at least one character.
+func (r Range) Overlaps(o Range) bool {
+	return r.Start.Before(o.End) && o.Start.Before(r.End)
+}
+
+// Location represents a location inside a resource, such as a line inside
+// a text file.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#location
+type Location struct {
+	URI   DocumentURI `json:"uri"`
+	Range Range       `json:"range"`
+}
+
+// NewLocation returns the location of rng in the document at uri.
+func NewLocation(uri DocumentURI, rng Range) Location {
+	return Location{URI: uri, Range: rng}
+}
+
+// LocationLink represents a link between a source and a target location.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#locationLink
+type LocationLink struct {
+	// OriginSelectionRange is the span of the origin of the link, used as
+	// the underlined span for mouse interaction. If nil, the word range at
+	// the request position is used.
+	OriginSelectionRange *Range `json:"originSelectionRange,omitempty"`
+
+	// TargetURI is the target resource of the link.
+	TargetURI DocumentURI `json:"targetUri"`
+
+	// TargetRange is the full target range, such as the body of a function
+	// including its comments.
+	TargetRange Range `json:"targetRange"`
+
+	// TargetSelectionRange is the range to select and reveal when the link
+	// is followed, such as the name of a function. It must be contained in
+	// TargetRange.
+	TargetSelectionRange Range `json:"targetSelectionRange"`
+}
+
+// TextDocumentIdentifier identifies a text document by its URI.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocumentIdentifier
+type TextDocumentIdentifier struct {
+	URI DocumentURI `json:"uri"`
+}
+
+// VersionedTextDocumentIdentifier identifies a specific version of a text
+// document.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#versionedTextDocumentIdentifier
+type VersionedTextDocumentIdentifier struct {
+	TextDocumentIdentifier
+
+	// Version increases after each change, including undo and redo.
+	Version Integer `json:"version"`
+}
+
+// NewVersionedTextDocumentIdentifier returns the identifier of version of
+// the document at uri.
+func NewVersionedTextDocumentIdentifier(uri DocumentURI, version Integer) VersionedTextDocumentIdentifier {
+	return VersionedTextDocumentIdentifier{
+		TextDocumentIdentifier: TextDocumentIdentifier{URI: uri},
+		Version:                version,
+	}
+}
+
+// Optional returns id as an OptionalVersionedTextDocumentIdentifier, as used
+// by TextDocumentEdit.
+func (id VersionedTextDocumentIdentifier) Optional() OptionalVersionedTextDocumentIdentifier {
+	version := id.Version
+	return OptionalVersionedTextDocumentIdentifier{
+		TextDocumentIdentifier: id.TextDocumentIdentifier,
+		Version:                &version,
+	}
+}
+
+// OptionalVersionedTextDocumentIdentifier identifies a text document,
+// optionally at a specific version.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#optionalVersionedTextDocumentIdentifier
+type OptionalVersionedTextDocumentIdentifier struct {
+	TextDocumentIdentifier
+
+	// Version is the version of the document, or nil if the document is
+	// not open and its content on disk is the truth.
+	Version *Integer `json:"version"`
+}
+
+// TextDocumentItem represents a text document transferred from the client
+// to the server.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocumentItem
+type TextDocumentItem struct {
+	URI DocumentURI `json:"uri"`
+
+	// LanguageID is the language identifier of the document, such as "go".
+	LanguageID string `json:"languageId"`
+
+	// Version increases after each change, including undo and redo.
+	Version Integer `json:"version"`
+
+	// Text is the content of the document.
+	Text string `json:"text"`
+}
+
+// Identifier returns the versioned identifier of the document.
+func (d TextDocumentItem) Identifier() VersionedTextDocumentIdentifier {
+	return NewVersionedTextDocumentIdentifier(d.URI, d.Version)
+}
+
+// TextDocumentPositionParams are the parameters of requests about a position
+// in a text document. Positional request params embed them.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocumentPositionParams
+type TextDocumentPositionParams struct {
+	// TextDocument is the document.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+
+	// Position is the position inside the document.
+	Position Position `json:"position"`
+}
+
+// NewTextDocumentPositionParams returns the parameters of a request about
+// pos in the document at uri.
+func NewTextDocumentPositionParams(uri DocumentURI, pos Position) TextDocumentPositionParams {
+	return TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: uri},
+		Position:     pos,
+	}
+}
+
+// TextEdit represents a textual edit to a document.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textEdit
+type TextEdit struct {
+	// Range is the range of the document to replace. To insert text, use
+	// an empty range.
+	Range Range `json:"range"`
+
+	// NewText is the text to replace Range with. To delete text, use an
+	// empty string.
+	NewText string `json:"newText"`
+}
+
+// Command represents a reference to a command, which is run by the client,
+// typically by sending a workspace/executeCommand request to the server.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#command
+type Command struct {
+	// Title is the title of the command, such as "save".
+	Title string `json:"title"`
+
+	// Command is the identifier of the command.
+	Command string `json:"command"`
+
+	// Arguments are the arguments the command is invoked with.
+	Arguments []LSPAny `json:"arguments,omitempty"`
+}
@@ -0,0 +1,134 @@
+package golsptoolkit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// BatchPolicy selects how a JSONRPCConn treats incoming JSON-RPC batches,
+// arrays of messages sent as one. LSP itself never batches, but some
+// JSON-RPC tooling does.
+type BatchPolicy int
+
+const (
+	// BatchReject answers every batch with a single InvalidRequest error.
+	BatchReject BatchPolicy = iota
+
+	// BatchProcess handles the messages of a batch as if they had arrived
+	// one by one, and answers the requests among them with one array once
+	// they have all been answered.
+	BatchProcess
+)
+
+// EncodeBatch encodes msgs, such as *RequestMessage and
+// *NotificationMessage values, as a single batch message.
+func EncodeBatch(msgs ...any) ([]byte, error) {
+	return json.Marshal(msgs)
+}
+
+// readBatch handles a batch message according to the Batch policy.
+func (c *JSONRPCConn) readBatch(ctx context.Context, body []byte) error {
+	if c.Batch != BatchProcess {
+		c.logf("rejected batch message")
+		return c.write(ctx, errorResponse(nil, NewError(InvalidRequest, "batch messages are not supported")))
+	}
+
+	var elems []json.RawMessage
+	if err := json.Unmarshal(body, &elems); err != nil {
+		return c.write(ctx, errorResponse(nil, NewError(ParseError, err.Error())))
+	}
+	if len(elems) == 0 {
+		return c.write(ctx, errorResponse(nil, NewError(InvalidRequest, "empty batch")))
+	}
+
+	b := &batchReply{conn: c}
+	for _, elem := range elems {
+		if expectsAnswer(elem) {
+			b.expect()
+		}
+		if err := c.route(ctx, elem, b); err != nil {
+			return err
+		}
+	}
+	b.seal(ctx)
+	return nil
+}
+
+// expectsAnswer reports whether the batch element body is answered: it is
+// a request other than $/cancelRequest, or too malformed to tell.
+func expectsAnswer(body json.RawMessage) bool {
+	msg, rerr := decodeMessage(body)
+	defer releaseWireMessage(msg)
+	if rerr != nil {
+		return msg.answersRejection()
+	}
+	return msg.Method != "" && msg.ID != nil && msg.Method != MethodCancelRequest
+}
+
+// batchReply collects the answers to the requests of a batch.
+type batchReply struct {
+	conn *JSONRPCConn
+
+	mu      sync.Mutex
+	pending int
+	sealed  bool
+	answers []json.RawMessage
+}
+
+func (b *batchReply) expect() {
+	b.mu.Lock()
+	b.pending++
+	b.mu.Unlock()
+}
+
+// add records an answer, and sends them all once the last one is in.
+func (b *batchReply) add(ctx context.Context, answer []byte) error {
+	b.mu.Lock()
+	b.answers = append(b.answers, answer)
+	b.pending--
+	flush := b.sealed && b.pending == 0
+	b.mu.Unlock()
+	if flush {
+		return b.flush(ctx)
+	}
+	return nil
+}
+
+// seal marks the end of the batch, after which the answers are sent as soon
+// as they are all in.
+func (b *batchReply) seal(ctx context.Context) {
+	b.mu.Lock()
+	b.sealed = true
+	flush := b.pending == 0 && len(b.answers) > 0
+	b.mu.Unlock()
+	if flush {
+		b.flush(ctx)
+	}
+}
+
+func (b *batchReply) flush(ctx context.Context) error {
+	body, err := json.Marshal(b.answers)
+	if err != nil {
+		return err
+	}
+	return b.conn.conn.WriteMessage(ctx, body)
+}
+
+// respond sends msg, or adds it to the answers of batch b if non-nil.
+func (c *JSONRPCConn) respond(ctx context.Context, b *batchReply, msg *ResponseMessage) error {
+	if b == nil {
+		return c.write(ctx, msg)
+	}
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return b.add(ctx, body)
+}
+
+func isJSONArray(body []byte) bool {
+	body = bytes.TrimSpace(body)
+	return len(body) > 0 && body[0] == '['
+}
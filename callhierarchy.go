@@ -0,0 +1,119 @@
+package golsptoolkit
+
+// Methods of call hierarchy.
+const (
+	MethodTextDocumentPrepareCallHierarchy = "textDocument/prepareCallHierarchy"
+	MethodCallHierarchyIncomingCalls       = "callHierarchy/incomingCalls"
+	MethodCallHierarchyOutgoingCalls       = "callHierarchy/outgoingCalls"
+)
+
+// CallHierarchyOptions represents the call hierarchy options a server
+// announces in ServerCapabilities.CallHierarchyProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#callHierarchyOptions
+type CallHierarchyOptions struct {
+	WorkDoneProgressOptions
+}
+
+// CallHierarchyRegistrationOptions are the options of a dynamic or static
+// registration of call hierarchy.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#callHierarchyRegistrationOptions
+type CallHierarchyRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	CallHierarchyOptions
+	StaticRegistrationOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (CallHierarchyRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentPrepareCallHierarchy
+}
+
+// CallHierarchyPrepareParams are the parameters of the
+// textDocument/prepareCallHierarchy request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#callHierarchyPrepareParams
+type CallHierarchyPrepareParams struct {
+	TextDocumentPositionParams
+	WorkDoneProgressParams
+}
+
+// CallHierarchyItem represents a function, method, or other callable in the
+// call hierarchy.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#callHierarchyItem
+type CallHierarchyItem struct {
+	// Name is the name of the item.
+	Name string `json:"name"`
+
+	// Kind is the kind of the item.
+	Kind SymbolKind `json:"kind"`
+
+	// Tags tweak the rendering of the item.
+	Tags []SymbolTag `json:"tags,omitempty"`
+
+	// Detail is more detail for the item, such as a function signature.
+	Detail string `json:"detail,omitempty"`
+
+	// URI is the document of the item.
+	URI DocumentURI `json:"uri"`
+
+	// Range encloses the item, including leading and trailing comments.
+	Range Range `json:"range"`
+
+	// SelectionRange is selected and revealed when the item is picked,
+	// such as the name of a function. It must be contained in Range.
+	SelectionRange Range `json:"selectionRange"`
+
+	// Data is preserved by the client between a prepareCallHierarchy
+	// request and the incomingCalls and outgoingCalls requests.
+	Data LSPAny `json:"data,omitempty"`
+}
+
+// CallHierarchyIncomingCallsParams are the parameters of the
+// callHierarchy/incomingCalls request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#callHierarchyIncomingCallsParams
+type CallHierarchyIncomingCallsParams struct {
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// Item is the item to find the callers of.
+	Item CallHierarchyItem `json:"item"`
+}
+
+// CallHierarchyIncomingCall represents a caller of an item.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#callHierarchyIncomingCall
+type CallHierarchyIncomingCall struct {
+	// From is the item that makes the calls.
+	From CallHierarchyItem `json:"from"`
+
+	// FromRanges are the ranges of the calls, relative to From.
+	FromRanges []Range `json:"fromRanges"`
+}
+
+// CallHierarchyOutgoingCallsParams are the parameters of the
+// callHierarchy/outgoingCalls request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#callHierarchyOutgoingCallsParams
+type CallHierarchyOutgoingCallsParams struct {
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// Item is the item to find the callees of.
+	Item CallHierarchyItem `json:"item"`
+}
+
+// CallHierarchyOutgoingCall represents a callee of an item.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#callHierarchyOutgoingCall
+type CallHierarchyOutgoingCall struct {
+	// To is the item that is called.
+	To CallHierarchyItem `json:"to"`
+
+	// FromRanges are the ranges of the calls, relative to the item the
+	// request was for, not to To.
+	FromRanges []Range `json:"fromRanges"`
+}
@@ -0,0 +1,1098 @@
+package golsptoolkit
+
+import "encoding/json"
+
+// ClientCapabilities represents the capabilities a client announces in the
+// initialize request. A missing capability means the client does not
+// support the feature.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#clientCapabilities
+type ClientCapabilities struct {
+	// Workspace are the workspace specific client capabilities.
+	Workspace *WorkspaceClientCapabilities `json:"workspace,omitempty"`
+
+	// TextDocument are the text document specific client capabilities.
+	TextDocument *TextDocumentClientCapabilities `json:"textDocument,omitempty"`
+
+	// NotebookDocument are the notebook document specific client
+	// capabilities.
+	NotebookDocument *NotebookDocumentClientCapabilities `json:"notebookDocument,omitempty"`
+
+	// Window are the window specific client capabilities.
+	Window *WindowClientCapabilities `json:"window,omitempty"`
+
+	// General are the general client capabilities.
+	General *GeneralClientCapabilities `json:"general,omitempty"`
+
+	// Experimental are experimental client capabilities.
+	Experimental LSPAny `json:"experimental,omitempty"`
+}
+
+// WorkspaceClientCapabilities are the workspace specific client
+// capabilities.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#clientCapabilities
+type WorkspaceClientCapabilities struct {
+	// ApplyEdit reports whether the client supports applying batch edits
+	// with the workspace/applyEdit request.
+	ApplyEdit bool `json:"applyEdit,omitempty"`
+
+	WorkspaceEdit          *WorkspaceEditClientCapabilities          `json:"workspaceEdit,omitempty"`
+	DidChangeConfiguration *DidChangeConfigurationClientCapabilities `json:"didChangeConfiguration,omitempty"`
+	DidChangeWatchedFiles  *DidChangeWatchedFilesClientCapabilities  `json:"didChangeWatchedFiles,omitempty"`
+	Symbol                 *WorkspaceSymbolClientCapabilities        `json:"symbol,omitempty"`
+	ExecuteCommand         *ExecuteCommandClientCapabilities         `json:"executeCommand,omitempty"`
+
+	// WorkspaceFolders reports whether the client supports workspace
+	// folders.
+	WorkspaceFolders bool `json:"workspaceFolders,omitempty"`
+
+	// Configuration reports whether the client supports the
+	// workspace/configuration request.
+	Configuration bool `json:"configuration,omitempty"`
+
+	SemanticTokens *SemanticTokensWorkspaceClientCapabilities `json:"semanticTokens,omitempty"`
+	CodeLens       *CodeLensWorkspaceClientCapabilities       `json:"codeLens,omitempty"`
+	FileOperations *FileOperationClientCapabilities           `json:"fileOperations,omitempty"`
+	InlineValue    *InlineValueWorkspaceClientCapabilities    `json:"inlineValue,omitempty"`
+	InlayHint      *InlayHintWorkspaceClientCapabilities      `json:"inlayHint,omitempty"`
+	Diagnostics    *DiagnosticWorkspaceClientCapabilities     `json:"diagnostics,omitempty"`
+}
+
+// WorkspaceEditClientCapabilities are the client capabilities for workspace
+// edits.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspaceEditClientCapabilities
+type WorkspaceEditClientCapabilities struct {
+	// DocumentChanges reports whether the client supports versioned
+	// document changes.
+	DocumentChanges bool `json:"documentChanges,omitempty"`
+
+	// ResourceOperations are the resource operations the client supports.
+	ResourceOperations []ResourceOperationKind `json:"resourceOperations,omitempty"`
+
+	// FailureHandling is how the client handles a failing edit.
+	FailureHandling FailureHandlingKind `json:"failureHandling,omitempty"`
+
+	// NormalizesLineEndings reports whether the client normalizes line
+	// endings to the client specific setting.
+	NormalizesLineEndings bool `json:"normalizesLineEndings,omitempty"`
+
+	// ChangeAnnotationSupport is set if the client supports change
+	// annotations on text edits and resource operations.
+	ChangeAnnotationSupport *ChangeAnnotationSupport `json:"changeAnnotationSupport,omitempty"`
+}
+
+// ChangeAnnotationSupport describes the client's support for change
+// annotations.
+type ChangeAnnotationSupport struct {
+	// GroupsOnLabel reports whether the client groups edits with equal
+	// labels into tree nodes.
+	GroupsOnLabel bool `json:"groupsOnLabel,omitempty"`
+}
+
+// DidChangeConfigurationClientCapabilities are the client capabilities for
+// the workspace/didChangeConfiguration notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspace_didChangeConfiguration
+type DidChangeConfigurationClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
+
+// DidChangeWatchedFilesClientCapabilities are the client capabilities for
+// the workspace/didChangeWatchedFiles notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspace_didChangeWatchedFiles
+type DidChangeWatchedFilesClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	// RelativePatternSupport reports whether the client supports relative
+	// patterns.
+	RelativePatternSupport bool `json:"relativePatternSupport,omitempty"`
+}
+
+// WorkspaceSymbolClientCapabilities are the client capabilities for the
+// workspace/symbol request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspace_symbol
+type WorkspaceSymbolClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	// SymbolKind lists the symbol kinds the client supports.
+	SymbolKind *SymbolKindClientCapabilities `json:"symbolKind,omitempty"`
+
+	// TagSupport lists the symbol tags the client supports.
+	TagSupport *SymbolTagSupport `json:"tagSupport,omitempty"`
+
+	// ResolveSupport lists the properties the client can resolve lazily
+	// with workspaceSymbol/resolve.
+	ResolveSupport *ResolveSupport `json:"resolveSupport,omitempty"`
+}
+
+// ExecuteCommandClientCapabilities are the client capabilities for the
+// workspace/executeCommand request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspace_executeCommand
+type ExecuteCommandClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
+
+// SemanticTokensWorkspaceClientCapabilities are the workspace client
+// capabilities for semantic tokens.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_semanticTokens
+type SemanticTokensWorkspaceClientCapabilities struct {
+	// RefreshSupport reports whether the client supports the
+	// workspace/semanticTokens/refresh request.
+	RefreshSupport bool `json:"refreshSupport,omitempty"`
+}
+
+// CodeLensWorkspaceClientCapabilities are the workspace client capabilities
+// for code lenses.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#codeLens_refresh
+type CodeLensWorkspaceClientCapabilities struct {
+	// RefreshSupport reports whether the client supports the
+	// workspace/codeLens/refresh request.
+	RefreshSupport bool `json:"refreshSupport,omitempty"`
+}
+
+// FileOperationClientCapabilities are the client capabilities for file
+// operation requests and notifications.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#clientCapabilities
+type FileOperationClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	DidCreate  bool `json:"didCreate,omitempty"`
+	WillCreate bool `json:"willCreate,omitempty"`
+	DidRename  bool `json:"didRename,omitempty"`
+	WillRename bool `json:"willRename,omitempty"`
+	DidDelete  bool `json:"didDelete,omitempty"`
+	WillDelete bool `json:"willDelete,omitempty"`
+}
+
+// InlineValueWorkspaceClientCapabilities are the workspace client
+// capabilities for inline values.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspace_inlineValue_refresh
+type InlineValueWorkspaceClientCapabilities struct {
+	// RefreshSupport reports whether the client supports the
+	// workspace/inlineValue/refresh request.
+	RefreshSupport bool `json:"refreshSupport,omitempty"`
+}
+
+// InlayHintWorkspaceClientCapabilities are the workspace client
+// capabilities for inlay hints.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspace_inlayHint_refresh
+type InlayHintWorkspaceClientCapabilities struct {
+	// RefreshSupport reports whether the client supports the
+	// workspace/inlayHint/refresh request.
+	RefreshSupport bool `json:"refreshSupport,omitempty"`
+}
+
+// DiagnosticWorkspaceClientCapabilities are the workspace client
+// capabilities for pull diagnostics.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#diagnostic_refresh
+type DiagnosticWorkspaceClientCapabilities struct {
+	// RefreshSupport reports whether the client supports the
+	// workspace/diagnostic/refresh request.
+	RefreshSupport bool `json:"refreshSupport,omitempty"`
+}
+
+// TextDocumentClientCapabilities are the text document specific client
+// capabilities.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocumentClientCapabilities
+type TextDocumentClientCapabilities struct {
+	Synchronization    *TextDocumentSyncClientCapabilities         `json:"synchronization,omitempty"`
+	Completion         *CompletionClientCapabilities               `json:"completion,omitempty"`
+	Hover              *HoverClientCapabilities                    `json:"hover,omitempty"`
+	SignatureHelp      *SignatureHelpClientCapabilities            `json:"signatureHelp,omitempty"`
+	Declaration        *DeclarationClientCapabilities              `json:"declaration,omitempty"`
+	Definition         *DefinitionClientCapabilities               `json:"definition,omitempty"`
+	TypeDefinition     *TypeDefinitionClientCapabilities           `json:"typeDefinition,omitempty"`
+	Implementation     *ImplementationClientCapabilities           `json:"implementation,omitempty"`
+	References         *ReferenceClientCapabilities                `json:"references,omitempty"`
+	DocumentHighlight  *DocumentHighlightClientCapabilities        `json:"documentHighlight,omitempty"`
+	DocumentSymbol     *DocumentSymbolClientCapabilities           `json:"documentSymbol,omitempty"`
+	CodeAction         *CodeActionClientCapabilities               `json:"codeAction,omitempty"`
+	CodeLens           *CodeLensClientCapabilities                 `json:"codeLens,omitempty"`
+	DocumentLink       *DocumentLinkClientCapabilities             `json:"documentLink,omitempty"`
+	ColorProvider      *DocumentColorClientCapabilities            `json:"colorProvider,omitempty"`
+	Formatting         *DocumentFormattingClientCapabilities       `json:"formatting,omitempty"`
+	RangeFormatting    *DocumentRangeFormattingClientCapabilities  `json:"rangeFormatting,omitempty"`
+	OnTypeFormatting   *DocumentOnTypeFormattingClientCapabilities `json:"onTypeFormatting,omitempty"`
+	Rename             *RenameClientCapabilities                   `json:"rename,omitempty"`
+	PublishDiagnostics *PublishDiagnosticsClientCapabilities       `json:"publishDiagnostics,omitempty"`
+	FoldingRange       *FoldingRangeClientCapabilities             `json:"foldingRange,omitempty"`
+	SelectionRange     *SelectionRangeClientCapabilities           `json:"selectionRange,omitempty"`
+	LinkedEditingRange *LinkedEditingRangeClientCapabilities       `json:"linkedEditingRange,omitempty"`
+	CallHierarchy      *CallHierarchyClientCapabilities            `json:"callHierarchy,omitempty"`
+	SemanticTokens     *SemanticTokensClientCapabilities           `json:"semanticTokens,omitempty"`
+	Moniker            *MonikerClientCapabilities                  `json:"moniker,omitempty"`
+	TypeHierarchy      *TypeHierarchyClientCapabilities            `json:"typeHierarchy,omitempty"`
+	InlineValue        *InlineValueClientCapabilities              `json:"inlineValue,omitempty"`
+	InlayHint          *InlayHintClientCapabilities                `json:"inlayHint,omitempty"`
+	Diagnostic         *DiagnosticClientCapabilities               `json:"diagnostic,omitempty"`
+}
+
+// TextDocumentSyncClientCapabilities are the client capabilities for text
+// document synchronization.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_synchronization
+type TextDocumentSyncClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	// WillSave reports whether the client sends willSave notifications.
+	WillSave bool `json:"willSave,omitempty"`
+
+	// WillSaveWaitUntil reports whether the client sends willSaveWaitUntil
+	// requests and waits for their response.
+	WillSaveWaitUntil bool `json:"willSaveWaitUntil,omitempty"`
+
+	// DidSave reports whether the client sends didSave notifications.
+	DidSave bool `json:"didSave,omitempty"`
+}
+
+// CompletionClientCapabilities are the client capabilities for the
+// textDocument/completion request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_completion
+type CompletionClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	// CompletionItem describes the client's support for completion item
+	// properties.
+	CompletionItem *CompletionItemClientCapabilities `json:"completionItem,omitempty"`
+
+	// CompletionItemKind lists the completion item kinds the client
+	// supports.
+	CompletionItemKind *CompletionItemKindClientCapabilities `json:"completionItemKind,omitempty"`
+
+	// InsertTextMode is the client's default insert text mode when an item
+	// does not set one.
+	InsertTextMode InsertTextMode `json:"insertTextMode,omitempty"`
+
+	// ContextSupport reports whether the client sends the completion
+	// context.
+	ContextSupport bool `json:"contextSupport,omitempty"`
+
+	// CompletionList describes the client's support for completion list
+	// properties.
+	CompletionList *CompletionListClientCapabilities `json:"completionList,omitempty"`
+}
+
+// CompletionItemClientCapabilities describes the client's support for
+// completion item properties.
+type CompletionItemClientCapabilities struct {
+	// SnippetSupport reports whether the client supports snippets as
+	// insert text.
+	SnippetSupport bool `json:"snippetSupport,omitempty"`
+
+	// CommitCharactersSupport reports whether the client supports commit
+	// characters.
+	CommitCharactersSupport bool `json:"commitCharactersSupport,omitempty"`
+
+	// DocumentationFormat lists the documentation formats the client
+	// supports, in order of preference.
+	DocumentationFormat []MarkupKind `json:"documentationFormat,omitempty"`
+
+	// DeprecatedSupport reports whether the client supports the deprecated
+	// property.
+	DeprecatedSupport bool `json:"deprecatedSupport,omitempty"`
+
+	// PreselectSupport reports whether the client supports the preselect
+	// property.
+	PreselectSupport bool `json:"preselectSupport,omitempty"`
+
+	// TagSupport lists the completion item tags the client supports.
+	TagSupport *CompletionItemTagSupport `json:"tagSupport,omitempty"`
+
+	// InsertReplaceSupport reports whether the client supports insert and
+	// replace edits.
+	InsertReplaceSupport bool `json:"insertReplaceSupport,omitempty"`
+
+	// ResolveSupport lists the properties the client can resolve lazily
+	// with completionItem/resolve.
+	ResolveSupport *ResolveSupport `json:"resolveSupport,omitempty"`
+
+	// InsertTextModeSupport lists the insert text modes the client
+	// supports.
+	InsertTextModeSupport *InsertTextModeSupport `json:"insertTextModeSupport,omitempty"`
+
+	// LabelDetailsSupport reports whether the client supports label
+	// details.
+	LabelDetailsSupport bool `json:"labelDetailsSupport,omitempty"`
+}
+
+// CompletionItemTagSupport lists the completion item tags a client
+// supports.
+type CompletionItemTagSupport struct {
+	ValueSet []CompletionItemTag `json:"valueSet"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Values of ValueSet this
+// package does not define, such as tags of later protocol versions, are
+// dropped.
+func (s *CompletionItemTagSupport) UnmarshalJSON(data []byte) error {
+	var v struct {
+		ValueSet []int64 `json:"valueSet"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	s.ValueSet = knownEnumValues[CompletionItemTag](v.ValueSet, completionItemTagNames)
+	return nil
+}
+
+// InsertTextModeSupport lists the insert text modes a client supports.
+type InsertTextModeSupport struct {
+	ValueSet []InsertTextMode `json:"valueSet"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Values of ValueSet this
+// package does not define, such as modes of later protocol versions, are
+// dropped.
+func (s *InsertTextModeSupport) UnmarshalJSON(data []byte) error {
+	var v struct {
+		ValueSet []int64 `json:"valueSet"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	s.ValueSet = knownEnumValues[InsertTextMode](v.ValueSet, insertTextModeNames)
+	return nil
+}
+
+// CompletionItemKindClientCapabilities lists the completion item kinds a
+// client supports. If ValueSet is empty, the client supports the kinds from
+// Text to Reference of the initial protocol version.
+type CompletionItemKindClientCapabilities struct {
+	ValueSet []CompletionItemKind `json:"valueSet,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Values of ValueSet this
+// package does not define, such as kinds of later protocol versions, are
+// dropped.
+func (s *CompletionItemKindClientCapabilities) UnmarshalJSON(data []byte) error {
+	var v struct {
+		ValueSet []int64 `json:"valueSet"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	s.ValueSet = knownEnumValues[CompletionItemKind](v.ValueSet, completionItemKindNames)
+	return nil
+}
+
+// CompletionListClientCapabilities describes the client's support for
+// completion list properties.
+type CompletionListClientCapabilities struct {
+	// ItemDefaults lists the item default properties the client supports.
+	ItemDefaults []string `json:"itemDefaults,omitempty"`
+}
+
+// ResolveSupport lists the properties a client can resolve lazily.
+type ResolveSupport struct {
+	Properties []string `json:"properties"`
+}
+
+// HoverClientCapabilities are the client capabilities for the
+// textDocument/hover request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_hover
+type HoverClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	// ContentFormat lists the content formats the client supports, in
+	// order of preference.
+	ContentFormat []MarkupKind `json:"contentFormat,omitempty"`
+}
+
+// SignatureHelpClientCapabilities are the client capabilities for the
+// textDocument/signatureHelp request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_signatureHelp
+type SignatureHelpClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	// SignatureInformation describes the client's support for signature
+	// information properties.
+	SignatureInformation *SignatureInformationClientCapabilities `json:"signatureInformation,omitempty"`
+
+	// ContextSupport reports whether the client sends the signature help
+	// context.
+	ContextSupport bool `json:"contextSupport,omitempty"`
+}
+
+// SignatureInformationClientCapabilities describes the client's support for
+// signature information properties.
+type SignatureInformationClientCapabilities struct {
+	// DocumentationFormat lists the documentation formats the client
+	// supports, in order of preference.
+	DocumentationFormat []MarkupKind `json:"documentationFormat,omitempty"`
+
+	// ParameterInformation describes the client's support for parameter
+	// information properties.
+	ParameterInformation *ParameterInformationClientCapabilities `json:"parameterInformation,omitempty"`
+
+	// ActiveParameterSupport reports whether the client supports the
+	// activeParameter property of signature information.
+	ActiveParameterSupport bool `json:"activeParameterSupport,omitempty"`
+}
+
+// ParameterInformationClientCapabilities describes the client's support for
+// parameter information properties.
+type ParameterInformationClientCapabilities struct {
+	// LabelOffsetSupport reports whether the client supports parameter
+	// labels given as offsets into the signature label.
+	LabelOffsetSupport bool `json:"labelOffsetSupport,omitempty"`
+}
+
+// DeclarationClientCapabilities are the client capabilities for the
+// textDocument/declaration request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_declaration
+type DeclarationClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	// LinkSupport reports whether the client supports LocationLink
+	// results.
+	LinkSupport bool `json:"linkSupport,omitempty"`
+}
+
+// DefinitionClientCapabilities are the client capabilities for the
+// textDocument/definition request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_definition
+type DefinitionClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	// LinkSupport reports whether the client supports LocationLink
+	// results.
+	LinkSupport bool `json:"linkSupport,omitempty"`
+}
+
+// TypeDefinitionClientCapabilities are the client capabilities for the
+// textDocument/typeDefinition request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_typeDefinition
+type TypeDefinitionClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	// LinkSupport reports whether the client supports LocationLink
+	// results.
+	LinkSupport bool `json:"linkSupport,omitempty"`
+}
+
+// ImplementationClientCapabilities are the client capabilities for the
+// textDocument/implementation request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_implementation
+type ImplementationClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	// LinkSupport reports whether the client supports LocationLink
+	// results.
+	LinkSupport bool `json:"linkSupport,omitempty"`
+}
+
+// ReferenceClientCapabilities are the client capabilities for the
+// textDocument/references request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_references
+type ReferenceClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
+
+// DocumentHighlightClientCapabilities are the client capabilities for the
+// textDocument/documentHighlight request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_documentHighlight
+type DocumentHighlightClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
+
+// DocumentSymbolClientCapabilities are the client capabilities for the
+// textDocument/documentSymbol request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_documentSymbol
+type DocumentSymbolClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	// SymbolKind lists the symbol kinds the client supports.
+	SymbolKind *SymbolKindClientCapabilities `json:"symbolKind,omitempty"`
+
+	// HierarchicalDocumentSymbolSupport reports whether the client
+	// supports hierarchical document symbols.
+	HierarchicalDocumentSymbolSupport bool `json:"hierarchicalDocumentSymbolSupport,omitempty"`
+
+	// TagSupport lists the symbol tags the client supports.
+	TagSupport *SymbolTagSupport `json:"tagSupport,omitempty"`
+
+	// LabelSupport reports whether the client shows a label for the
+	// document symbol outline.
+	LabelSupport bool `json:"labelSupport,omitempty"`
+}
+
+// SymbolKindClientCapabilities lists the symbol kinds a client supports. If
+// ValueSet is empty, the client supports the kinds from File to Array of the
+// initial protocol version.
+type SymbolKindClientCapabilities struct {
+	ValueSet []SymbolKind `json:"valueSet,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Values of ValueSet this
+// package does not define, such as kinds of later protocol versions, are
+// dropped.
+func (s *SymbolKindClientCapabilities) UnmarshalJSON(data []byte) error {
+	var v struct {
+		ValueSet []int64 `json:"valueSet"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	s.ValueSet = knownEnumValues[SymbolKind](v.ValueSet, symbolKindNames)
+	return nil
+}
+
+// SymbolTagSupport lists the symbol tags a client supports.
+type SymbolTagSupport struct {
+	ValueSet []SymbolTag `json:"valueSet"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Values of ValueSet this
+// package does not define, such as tags of later protocol versions, are
+// dropped.
+func (s *SymbolTagSupport) UnmarshalJSON(data []byte) error {
+	var v struct {
+		ValueSet []int64 `json:"valueSet"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	s.ValueSet = knownEnumValues[SymbolTag](v.ValueSet, symbolTagNames)
+	return nil
+}
+
+// CodeActionClientCapabilities are the client capabilities for the
+// textDocument/codeAction request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_codeAction
+type CodeActionClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	// CodeActionLiteralSupport is set if the client supports code action
+	// literals as results.
+	CodeActionLiteralSupport *CodeActionLiteralSupport `json:"codeActionLiteralSupport,omitempty"`
+
+	// IsPreferredSupport reports whether the client supports the
+	// isPreferred property.
+	IsPreferredSupport bool `json:"isPreferredSupport,omitempty"`
+
+	// DisabledSupport reports whether the client supports the disabled
+	// property.
+	DisabledSupport bool `json:"disabledSupport,omitempty"`
+
+	// DataSupport reports whether the client preserves the data property
+	// between a codeAction and a codeAction/resolve request.
+	DataSupport bool `json:"dataSupport,omitempty"`
+
+	// ResolveSupport lists the properties the client can resolve lazily
+	// with codeAction/resolve.
+	ResolveSupport *ResolveSupport `json:"resolveSupport,omitempty"`
+
+	// HonorsChangeAnnotations reports whether the client honors change
+	// annotations in the edits of code actions.
+	HonorsChangeAnnotations bool `json:"honorsChangeAnnotations,omitempty"`
+}
+
+// CodeActionLiteralSupport describes the client's support for code action
+// literals.
+type CodeActionLiteralSupport struct {
+	// CodeActionKind lists the code action kinds the client supports.
+	CodeActionKind CodeActionKindClientCapabilities `json:"codeActionKind"`
+}
+
+// CodeActionKindClientCapabilities lists the code action kinds a client
+// supports.
+type CodeActionKindClientCapabilities struct {
+	ValueSet []CodeActionKind `json:"valueSet"`
+}
+
+// CodeLensClientCapabilities are the client capabilities for the
+// textDocument/codeLens request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_codeLens
+type CodeLensClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
+
+// DocumentLinkClientCapabilities are the client capabilities for the
+// textDocument/documentLink request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_documentLink
+type DocumentLinkClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	// TooltipSupport reports whether the client supports the tooltip
+	// property.
+	TooltipSupport bool `json:"tooltipSupport,omitempty"`
+}
+
+// DocumentColorClientCapabilities are the client capabilities for the
+// textDocument/documentColor request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_documentColor
+type DocumentColorClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
+
+// DocumentFormattingClientCapabilities are the client capabilities for the
+// textDocument/formatting request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_formatting
+type DocumentFormattingClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
+
+// DocumentRangeFormattingClientCapabilities are the client capabilities for
+// the textDocument/rangeFormatting request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_rangeFormatting
+type DocumentRangeFormattingClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
+
+// DocumentOnTypeFormattingClientCapabilities are the client capabilities for
+// the textDocument/onTypeFormatting request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_onTypeFormatting
+type DocumentOnTypeFormattingClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
+
+// RenameClientCapabilities are the client capabilities for the
+// textDocument/rename request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_rename
+type RenameClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	// PrepareSupport reports whether the client supports the
+	// textDocument/prepareRename request.
+	PrepareSupport bool `json:"prepareSupport,omitempty"`
+
+	// PrepareSupportDefaultBehavior is the behavior the client applies
+	// when prepareRename answers with defaultBehavior.
+	PrepareSupportDefaultBehavior PrepareSupportDefaultBehavior `json:"prepareSupportDefaultBehavior,omitempty"`
+
+	// HonorsChangeAnnotations reports whether the client honors change
+	// annotations in the edits of renames.
+	HonorsChangeAnnotations bool `json:"honorsChangeAnnotations,omitempty"`
+}
+
+// PublishDiagnosticsClientCapabilities are the client capabilities for the
+// textDocument/publishDiagnostics notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_publishDiagnostics
+type PublishDiagnosticsClientCapabilities struct {
+	// RelatedInformation reports whether the client supports related
+	// information.
+	RelatedInformation bool `json:"relatedInformation,omitempty"`
+
+	// TagSupport lists the diagnostic tags the client supports.
+	TagSupport *DiagnosticTagSupport `json:"tagSupport,omitempty"`
+
+	// VersionSupport reports whether the client interprets the version
+	// property.
+	VersionSupport bool `json:"versionSupport,omitempty"`
+
+	// CodeDescriptionSupport reports whether the client supports the
+	// codeDescription property.
+	CodeDescriptionSupport bool `json:"codeDescriptionSupport,omitempty"`
+
+	// DataSupport reports whether the client preserves the data property
+	// between publishDiagnostics and codeAction requests.
+	DataSupport bool `json:"dataSupport,omitempty"`
+}
+
+// DiagnosticTagSupport lists the diagnostic tags a client supports.
+type DiagnosticTagSupport struct {
+	ValueSet []DiagnosticTag `json:"valueSet"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Values of ValueSet this
+// package does not define, such as tags of later protocol versions, are
+// dropped.
+func (s *DiagnosticTagSupport) UnmarshalJSON(data []byte) error {
+	var v struct {
+		ValueSet []int64 `json:"valueSet"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	s.ValueSet = knownEnumValues[DiagnosticTag](v.ValueSet, diagnosticTagNames)
+	return nil
+}
+
+// FoldingRangeClientCapabilities are the client capabilities for the
+// textDocument/foldingRange request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_foldingRange
+type FoldingRangeClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	// RangeLimit is the maximum number of folding ranges the client
+	// prefers per document. It is a hint; zero means no limit.
+	RangeLimit UInteger `json:"rangeLimit,omitempty"`
+
+	// LineFoldingOnly reports whether the client ignores the start and end
+	// characters of ranges and folds whole lines only.
+	LineFoldingOnly bool `json:"lineFoldingOnly,omitempty"`
+
+	// FoldingRangeKind lists the folding range kinds the client supports.
+	FoldingRangeKind *FoldingRangeKindClientCapabilities `json:"foldingRangeKind,omitempty"`
+
+	// FoldingRange describes the client's support for folding range
+	// properties.
+	FoldingRange *FoldingRangePropertiesClientCapabilities `json:"foldingRange,omitempty"`
+}
+
+// FoldingRangeKindClientCapabilities lists the folding range kinds a client
+// supports.
+type FoldingRangeKindClientCapabilities struct {
+	ValueSet []FoldingRangeKind `json:"valueSet,omitempty"`
+}
+
+// FoldingRangePropertiesClientCapabilities describes the client's support
+// for folding range properties.
+type FoldingRangePropertiesClientCapabilities struct {
+	// CollapsedText reports whether the client supports the collapsedText
+	// property.
+	CollapsedText bool `json:"collapsedText,omitempty"`
+}
+
+// SelectionRangeClientCapabilities are the client capabilities for the
+// textDocument/selectionRange request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_selectionRange
+type SelectionRangeClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
+
+// LinkedEditingRangeClientCapabilities are the client capabilities for the
+// textDocument/linkedEditingRange request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_linkedEditingRange
+type LinkedEditingRangeClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
+
+// CallHierarchyClientCapabilities are the client capabilities for the
+// textDocument/prepareCallHierarchy request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_prepareCallHierarchy
+type CallHierarchyClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
+
+// SemanticTokensClientCapabilities are the client capabilities for the
+// semantic tokens requests.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_semanticTokens
+type SemanticTokensClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	// Requests lists the semantic tokens requests the client sends.
+	Requests SemanticTokensRequestsClientCapabilities `json:"requests"`
+
+	// TokenTypes lists the token types the client supports.
+	TokenTypes []string `json:"tokenTypes"`
+
+	// TokenModifiers lists the token modifiers the client supports.
+	TokenModifiers []string `json:"tokenModifiers"`
+
+	// Formats lists the token formats the client supports.
+	Formats []TokenFormat `json:"formats"`
+
+	// OverlappingTokenSupport reports whether the client supports tokens
+	// that overlap each other.
+	OverlappingTokenSupport bool `json:"overlappingTokenSupport,omitempty"`
+
+	// MultilineTokenSupport reports whether the client supports tokens
+	// that span multiple lines.
+	MultilineTokenSupport bool `json:"multilineTokenSupport,omitempty"`
+
+	// ServerCancelSupport reports whether the client allows the server to
+	// cancel a semantic tokens request with ServerCancelled.
+	ServerCancelSupport bool `json:"serverCancelSupport,omitempty"`
+
+	// AugmentsSyntaxTokens reports whether the client combines semantic
+	// tokens with its own syntax highlighting.
+	AugmentsSyntaxTokens bool `json:"augmentsSyntaxTokens,omitempty"`
+}
+
+// SemanticTokensRequestsClientCapabilities lists the semantic tokens
+// requests a client sends.
+type SemanticTokensRequestsClientCapabilities struct {
+	// Range is true or an empty object if the client sends the
+	// textDocument/semanticTokens/range request.
+	Range Or2[bool, struct{}] `json:"range,omitzero"`
+
+	// Full is true, or an object, if the client sends the
+	// textDocument/semanticTokens/full request.
+	Full Or2[bool, SemanticTokensFullRequestsClientCapabilities] `json:"full,omitzero"`
+}
+
+// SemanticTokensFullRequestsClientCapabilities describes the client's
+// support for full semantic tokens requests.
+type SemanticTokensFullRequestsClientCapabilities struct {
+	// Delta reports whether the client sends the
+	// textDocument/semanticTokens/full/delta request.
+	Delta bool `json:"delta,omitempty"`
+}
+
+// MonikerClientCapabilities are the client capabilities for the
+// textDocument/moniker request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_moniker
+type MonikerClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
+
+// TypeHierarchyClientCapabilities are the client capabilities for the
+// textDocument/prepareTypeHierarchy request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_prepareTypeHierarchy
+type TypeHierarchyClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
+
+// InlineValueClientCapabilities are the client capabilities for the
+// textDocument/inlineValue request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_inlineValue
+type InlineValueClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
+
+// InlayHintClientCapabilities are the client capabilities for the
+// textDocument/inlayHint request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_inlayHint
+type InlayHintClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	// ResolveSupport lists the properties the client can resolve lazily
+	// with inlayHint/resolve.
+	ResolveSupport *ResolveSupport `json:"resolveSupport,omitempty"`
+}
+
+// DiagnosticClientCapabilities are the client capabilities for the
+// textDocument/diagnostic request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_pullDiagnostics
+type DiagnosticClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	// RelatedDocumentSupport reports whether the client supports related
+	// documents in document diagnostic reports.
+	RelatedDocumentSupport bool `json:"relatedDocumentSupport,omitempty"`
+}
+
+// NotebookDocumentClientCapabilities are the notebook document specific
+// client capabilities.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#notebookDocument_synchronization
+type NotebookDocumentClientCapabilities struct {
+	// Synchronization are the capabilities for notebook document
+	// synchronization.
+	Synchronization NotebookDocumentSyncClientCapabilities `json:"synchronization"`
+}
+
+// NotebookDocumentSyncClientCapabilities are the client capabilities for
+// notebook document synchronization.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#notebookDocument_synchronization
+type NotebookDocumentSyncClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+
+	// ExecutionSummarySupport reports whether the client supports
+	// execution summaries on notebook cells.
+	ExecutionSummarySupport bool `json:"executionSummarySupport,omitempty"`
+}
+
+// WindowClientCapabilities are the window specific client capabilities.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#clientCapabilities
+type WindowClientCapabilities struct {
+	// WorkDoneProgress reports whether the client supports server
+	// initiated work done progress.
+	WorkDoneProgress bool `json:"workDoneProgress,omitempty"`
+
+	ShowMessage  *ShowMessageRequestClientCapabilities `json:"showMessage,omitempty"`
+	ShowDocument *ShowDocumentClientCapabilities       `json:"showDocument,omitempty"`
+}
+
+// ShowMessageRequestClientCapabilities are the client capabilities for the
+// window/showMessageRequest request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#window_showMessageRequest
+type ShowMessageRequestClientCapabilities struct {
+	// MessageActionItem describes the client's support for message action
+	// items.
+	MessageActionItem *MessageActionItemClientCapabilities `json:"messageActionItem,omitempty"`
+}
+
+// MessageActionItemClientCapabilities describes the client's support for
+// message action items.
+type MessageActionItemClientCapabilities struct {
+	// AdditionalPropertiesSupport reports whether the client sends back
+	// properties of the chosen item other than its title.
+	AdditionalPropertiesSupport bool `json:"additionalPropertiesSupport,omitempty"`
+}
+
+// ShowDocumentClientCapabilities are the client capabilities for the
+// window/showDocument request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#window_showDocument
+type ShowDocumentClientCapabilities struct {
+	// Support reports whether the client supports the request.
+	Support bool `json:"support"`
+}
+
+// GeneralClientCapabilities are the general client capabilities.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#clientCapabilities
+type GeneralClientCapabilities struct {
+	// StaleRequestSupport describes how the client handles stale
+	// requests.
+	StaleRequestSupport *StaleRequestSupportClientCapabilities `json:"staleRequestSupport,omitempty"`
+
+	RegularExpressions *RegularExpressionsClientCapabilities `json:"regularExpressions,omitempty"`
+	Markdown           *MarkdownClientCapabilities           `json:"markdown,omitempty"`
+
+	// PositionEncodings lists the position encodings the client supports,
+	// in order of preference. If omitted, only UTF-16 is supported.
+	PositionEncodings []PositionEncodingKind `json:"positionEncodings,omitempty"`
+}
+
+// StaleRequestSupportClientCapabilities describes how a client handles
+// requests whose result is stale.
+type StaleRequestSupportClientCapabilities struct {
+	// Cancel reports whether the client cancels stale requests itself.
+	Cancel bool `json:"cancel"`
+
+	// RetryOnContentModified lists the methods the client retries when
+	// the server answers with ContentModified.
+	RetryOnContentModified []string `json:"retryOnContentModified"`
+}
+
+// RegularExpressionsClientCapabilities describes the regular expression
+// engine the client uses.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#regExp
+type RegularExpressionsClientCapabilities struct {
+	// Engine is the name of the engine, such as "ECMAScript".
+	Engine string `json:"engine"`
+
+	// Version is the version of the engine.
+	Version string `json:"version,omitempty"`
+}
+
+// MarkdownClientCapabilities describes the Markdown parser the client uses.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#markupContent
+type MarkdownClientCapabilities struct {
+	// Parser is the name of the parser, such as "marked".
+	Parser string `json:"parser"`
+
+	// Version is the version of the parser.
+	Version string `json:"version,omitempty"`
+
+	// AllowedTags lists the HTML tags the client allows in Markdown.
+	AllowedTags []string `json:"allowedTags,omitempty"`
+}
+
+// ServerCapabilities represents the capabilities a server announces in the
+// initialize result.
+//
+// Provider fields whose specification type is a union, such as
+// boolean | HoverOptions, are Or2 or Or3 values; a zero value omits the
+// property, meaning the feature is not provided:
+//
+//	caps := golsptoolkit.ServerCapabilities{
+//		HoverProvider: golsptoolkit.NewOr2A[bool, golsptoolkit.HoverOptions](true),
+//		CompletionProvider: &golsptoolkit.CompletionOptions{
+//			TriggerCharacters: []string{"."},
+//		},
+//	}
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#serverCapabilities
+type ServerCapabilities struct {
+	// PositionEncoding is the position encoding the server picked from
+	// the encodings offered by the client. If omitted, it is UTF-16.
+	PositionEncoding PositionEncodingKind `json:"positionEncoding,omitempty"`
+
+	// TextDocumentSync is how text documents are synced to the server.
+	TextDocumentSync Or2[TextDocumentSyncOptions, TextDocumentSyncKind] `json:"textDocumentSync,omitzero"`
+
+	// NotebookDocumentSync is how notebook documents are synced to the
+	// server.
+	NotebookDocumentSync Or2[NotebookDocumentSyncOptions, NotebookDocumentSyncRegistrationOptions] `json:"notebookDocumentSync,omitzero"`
+
+	CompletionProvider               *CompletionOptions                                                          `json:"completionProvider,omitempty"`
+	HoverProvider                    Or2[bool, HoverOptions]                                                     `json:"hoverProvider,omitzero"`
+	SignatureHelpProvider            *SignatureHelpOptions                                                       `json:"signatureHelpProvider,omitempty"`
+	DeclarationProvider              Or3[bool, DeclarationOptions, DeclarationRegistrationOptions]               `json:"declarationProvider,omitzero"`
+	DefinitionProvider               Or2[bool, DefinitionOptions]                                                `json:"definitionProvider,omitzero"`
+	TypeDefinitionProvider           Or3[bool, TypeDefinitionOptions, TypeDefinitionRegistrationOptions]         `json:"typeDefinitionProvider,omitzero"`
+	ImplementationProvider           Or3[bool, ImplementationOptions, ImplementationRegistrationOptions]         `json:"implementationProvider,omitzero"`
+	ReferencesProvider               Or2[bool, ReferenceOptions]                                                 `json:"referencesProvider,omitzero"`
+	DocumentHighlightProvider        Or2[bool, DocumentHighlightOptions]                                         `json:"documentHighlightProvider,omitzero"`
+	DocumentSymbolProvider           Or2[bool, DocumentSymbolOptions]                                            `json:"documentSymbolProvider,omitzero"`
+	CodeActionProvider               Or2[bool, CodeActionOptions]                                                `json:"codeActionProvider,omitzero"`
+	CodeLensProvider                 *CodeLensOptions                                                            `json:"codeLensProvider,omitempty"`
+	DocumentLinkProvider             *DocumentLinkOptions                                                        `json:"documentLinkProvider,omitempty"`
+	ColorProvider                    Or3[bool, DocumentColorOptions, DocumentColorRegistrationOptions]           `json:"colorProvider,omitzero"`
+	DocumentFormattingProvider       Or2[bool, DocumentFormattingOptions]                                        `json:"documentFormattingProvider,omitzero"`
+	DocumentRangeFormattingProvider  Or2[bool, DocumentRangeFormattingOptions]                                   `json:"documentRangeFormattingProvider,omitzero"`
+	DocumentOnTypeFormattingProvider *DocumentOnTypeFormattingOptions                                            `json:"documentOnTypeFormattingProvider,omitempty"`
+	RenameProvider                   Or2[bool, RenameOptions]                                                    `json:"renameProvider,omitzero"`
+	FoldingRangeProvider             Or3[bool, FoldingRangeOptions, FoldingRangeRegistrationOptions]             `json:"foldingRangeProvider,omitzero"`
+	ExecuteCommandProvider           *ExecuteCommandOptions                                                      `json:"executeCommandProvider,omitempty"`
+	SelectionRangeProvider           Or3[bool, SelectionRangeOptions, SelectionRangeRegistrationOptions]         `json:"selectionRangeProvider,omitzero"`
+	LinkedEditingRangeProvider       Or3[bool, LinkedEditingRangeOptions, LinkedEditingRangeRegistrationOptions] `json:"linkedEditingRangeProvider,omitzero"`
+	CallHierarchyProvider            Or3[bool, CallHierarchyOptions, CallHierarchyRegistrationOptions]           `json:"callHierarchyProvider,omitzero"`
+	SemanticTokensProvider           Or2[SemanticTokensOptions, SemanticTokensRegistrationOptions]               `json:"semanticTokensProvider,omitzero"`
+	MonikerProvider                  Or3[bool, MonikerOptions, MonikerRegistrationOptions]                       `json:"monikerProvider,omitzero"`
+	TypeHierarchyProvider            Or3[bool, TypeHierarchyOptions, TypeHierarchyRegistrationOptions]           `json:"typeHierarchyProvider,omitzero"`
+	InlineValueProvider              Or3[bool, InlineValueOptions, InlineValueRegistrationOptions]               `json:"inlineValueProvider,omitzero"`
+	InlayHintProvider                Or3[bool, InlayHintOptions, InlayHintRegistrationOptions]                   `json:"inlayHintProvider,omitzero"`
+	DiagnosticProvider               Or2[DiagnosticOptions, DiagnosticRegistrationOptions]                       `json:"diagnosticProvider,omitzero"`
+	WorkspaceSymbolProvider          Or2[bool, WorkspaceSymbolOptions]                                           `json:"workspaceSymbolProvider,omitzero"`
+
+	// Workspace are the workspace specific server capabilities.
+	Workspace *WorkspaceServerCapabilities `json:"workspace,omitempty"`
+
+	// Experimental are experimental server capabilities.
+	Experimental LSPAny `json:"experimental,omitempty"`
+}
+
+// WorkspaceServerCapabilities are the workspace specific server
+// capabilities.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#serverCapabilities
+type WorkspaceServerCapabilities struct {
+	// WorkspaceFolders describes the server's support for workspace
+	// folders.
+	WorkspaceFolders *WorkspaceFoldersServerCapabilities `json:"workspaceFolders,omitempty"`
+
+	// FileOperations lists the file operation requests and notifications
+	// the server is interested in.
+	FileOperations *FileOperationsServerCapabilities `json:"fileOperations,omitempty"`
+}
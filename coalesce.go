@@ -0,0 +1,130 @@
+package golsptoolkit
+
+import (
+	"context"
+	"runtime/debug"
+	"sync"
+)
+
+// CoalesceHandler is a Handler that collapses identical requests arriving
+// while one is already being computed, such as the burst of
+// textDocument/documentSymbol requests a client sends while the user types.
+// Requests with the same method about the same document share a single call
+// to Handler, and its result is sent to each of them.
+//
+// Only methods listed in Methods are coalesced, since requests about the
+// same document may differ in other parameters, such as the position of a
+// hover. A request that is canceled while waiting is answered with
+// RequestCancelled on its own; the shared computation is canceled once no
+// request waits for it anymore.
+type CoalesceHandler struct {
+	// Handler handles the messages.
+	Handler Handler
+
+	// Methods lists the methods whose requests are coalesced. Their
+	// result must depend on nothing but the document.
+	Methods map[string]bool
+
+	mu     sync.Mutex
+	groups map[coalesceKey]*coalesceGroup
+}
+
+type coalesceKey struct {
+	method string
+	uri    string
+}
+
+// coalesceGroup is a computation shared by the requests waiting for it.
+type coalesceGroup struct {
+	cancel  context.CancelFunc
+	waiters int
+
+	done   chan struct{}
+	result any
+	err    error
+}
+
+// Handle implements Handler.
+func (h *CoalesceHandler) Handle(ctx context.Context, reply Replier, req *Request) {
+	uri := RequestDocumentURI(req)
+	if req.IsNotification() || uri == "" || !h.Methods[req.Method] {
+		h.Handler.Handle(ctx, reply, req)
+		return
+	}
+	key := coalesceKey{req.Method, uri}
+
+	h.mu.Lock()
+	if h.groups == nil {
+		h.groups = make(map[coalesceKey]*coalesceGroup)
+	}
+	g := h.groups[key]
+	if g == nil {
+		// The computation belongs to no single request: it outlives the
+		// request that started it as long as others wait for it, and has
+		// a request state of its own, so that a Detach in Handler does not
+		// affect the request that started it.
+		gctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		st := new(requestState)
+		if rs, ok := ctx.Value(requestStateKey{}).(*requestState); ok {
+			st.conn = rs.conn
+		}
+		gctx = context.WithValue(gctx, requestStateKey{}, st)
+		g = &coalesceGroup{cancel: cancel, done: make(chan struct{})}
+		h.groups[key] = g
+		go h.run(gctx, key, g, req)
+	}
+	g.waiters++
+	h.mu.Unlock()
+
+	select {
+	case <-g.done:
+		reply(ctx, g.result, g.err)
+	case <-ctx.Done():
+		reply(ctx, nil, NewError(RequestCancelled, ctx.Err().Error()))
+	}
+
+	h.mu.Lock()
+	g.waiters--
+	if g.waiters == 0 {
+		g.cancel()
+		if h.groups[key] == g {
+			delete(h.groups, key)
+		}
+	}
+	h.mu.Unlock()
+}
+
+// run computes the result of g by calling Handler with req. A panic in
+// Handler is logged and answered with an InternalError, as JSONRPCConn would
+// have done had the call run on its goroutine.
+func (h *CoalesceHandler) run(ctx context.Context, key coalesceKey, g *coalesceGroup, req *Request) {
+	var once sync.Once
+	finish := func(result any, err error) {
+		once.Do(func() {
+			h.mu.Lock()
+			if h.groups[key] == g {
+				delete(h.groups, key)
+			}
+			h.mu.Unlock()
+
+			g.result, g.err = result, err
+			close(g.done)
+		})
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			logf(ctx, "panic handling %s: %v\n%s", req.Method, p, debug.Stack())
+			finish(nil, Errorf(InternalError, "panic handling %s: %v", req.Method, p))
+		}
+	}()
+
+	h.Handler.Handle(ctx, func(_ context.Context, result any, err error) error {
+		finish(result, err)
+		return nil
+	}, req)
+
+	if !ctx.Value(requestStateKey{}).(*requestState).detached.Load() {
+		finish(nil, NewError(InternalError, "request dropped by handler: "+req.Method))
+	}
+}
@@ -0,0 +1,197 @@
+package golsptoolkit
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Methods of code actions.
+const (
+	MethodTextDocumentCodeAction = "textDocument/codeAction"
+	MethodCodeActionResolve      = "codeAction/resolve"
+)
+
+// CodeActionKind represents the kind of a code action. Kinds are a
+// hierarchical list of identifiers separated by ".", such as
+// "refactor.extract.function".
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#codeActionKind
+type CodeActionKind = string
+
+const (
+	CodeActionKindEmpty                 CodeActionKind = ""
+	CodeActionKindQuickFix              CodeActionKind = "quickfix"
+	CodeActionKindRefactor              CodeActionKind = "refactor"
+	CodeActionKindRefactorExtract       CodeActionKind = "refactor.extract"
+	CodeActionKindRefactorInline        CodeActionKind = "refactor.inline"
+	CodeActionKindRefactorRewrite       CodeActionKind = "refactor.rewrite"
+	CodeActionKindSource                CodeActionKind = "source"
+	CodeActionKindSourceOrganizeImports CodeActionKind = "source.organizeImports"
+	CodeActionKindSourceFixAll          CodeActionKind = "source.fixAll"
+)
+
+// CodeActionOptions represents the code action options a server announces
+// in ServerCapabilities.CodeActionProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#codeActionOptions
+type CodeActionOptions struct {
+	WorkDoneProgressOptions
+
+	// CodeActionKinds lists the kinds of code actions the server may
+	// return, so the client can show them in its menus.
+	CodeActionKinds []CodeActionKind `json:"codeActionKinds,omitempty"`
+
+	// ResolveProvider reports whether the server computes additional
+	// properties of code actions with codeAction/resolve.
+	ResolveProvider bool `json:"resolveProvider,omitempty"`
+}
+
+// CodeActionRegistrationOptions are the options of a dynamic registration of
+// code actions.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#codeActionRegistrationOptions
+type CodeActionRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	CodeActionOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (CodeActionRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentCodeAction
+}
+
+// CodeActionParams are the parameters of the textDocument/codeAction
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#codeActionParams
+type CodeActionParams struct {
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// TextDocument is the document to compute code actions for.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+
+	// Range is the range to compute code actions for.
+	Range Range `json:"range"`
+
+	// Context carries additional information about the request.
+	Context CodeActionContext `json:"context"`
+}
+
+// CodeActionTriggerKind represents how code actions were requested.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#codeActionTriggerKind
+type CodeActionTriggerKind UInteger
+
+const (
+	// CodeActionTriggerKindInvoked is code actions requested explicitly by
+	// the user or by an extension.
+	CodeActionTriggerKindInvoked CodeActionTriggerKind = 1
+
+	// CodeActionTriggerKindAutomatic is code actions requested
+	// automatically, for example after the selection changed.
+	CodeActionTriggerKindAutomatic CodeActionTriggerKind = 2
+)
+
+var codeActionTriggerKindNames = enumNames{"CodeActionTriggerKind", []string{
+	CodeActionTriggerKindInvoked:   "Invoked",
+	CodeActionTriggerKindAutomatic: "Automatic",
+}}
+
+// String returns the name of k, such as "Invoked".
+func (k CodeActionTriggerKind) String() string {
+	return codeActionTriggerKindNames.name(int64(k))
+}
+
+// IsValid reports whether k is one of the defined code action trigger kinds.
+func (k CodeActionTriggerKind) IsValid() bool {
+	return codeActionTriggerKindNames.valid(int64(k))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Undefined values are rejected.
+func (k *CodeActionTriggerKind) UnmarshalJSON(data []byte) error {
+	return decodeEnum(data, k, codeActionTriggerKindNames)
+}
+
+// CodeActionContext carries additional information about a
+// textDocument/codeAction request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#codeActionContext
+type CodeActionContext struct {
+	// Diagnostics are the diagnostics known to the client that overlap
+	// the range.
+	Diagnostics []Diagnostic `json:"diagnostics"`
+
+	// Only lists the kinds of code actions requested. Actions that are
+	// not of these kinds, or of their sub-kinds, may be filtered out.
+	Only []CodeActionKind `json:"only,omitempty"`
+
+	// TriggerKind is how the code actions were requested.
+	TriggerKind CodeActionTriggerKind `json:"triggerKind,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. A nil Diagnostics is sent as an
+// empty array.
+func (c CodeActionContext) MarshalJSON() ([]byte, error) {
+	type context CodeActionContext
+	c.Diagnostics = nonNilDiagnostics(c.Diagnostics)
+	return json.Marshal(context(c))
+}
+
+// Wants reports whether a code action of kind was requested: whether Only is
+// empty, or kind is one of its kinds or of their sub-kinds. For example
+// "refactor.extract.function" is wanted if Only is ["refactor"].
+func (c CodeActionContext) Wants(kind CodeActionKind) bool {
+	if len(c.Only) == 0 {
+		return true
+	}
+	for _, only := range c.Only {
+		if kind == only || strings.HasPrefix(kind, only+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// CodeAction represents a change that can be performed in code, such as a
+// fix for a problem or a refactoring. If both Edit and Command are set, the
+// edit is applied first.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#codeAction
+type CodeAction struct {
+	// Title is a short, human-readable title for the action.
+	Title string `json:"title"`
+
+	// Kind is the kind of the action, used to filter actions.
+	Kind CodeActionKind `json:"kind,omitempty"`
+
+	// Diagnostics are the diagnostics the action resolves.
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+
+	// IsPreferred marks the action as the preferred one, for example the
+	// fix applied by an auto-fix command.
+	IsPreferred bool `json:"isPreferred,omitempty"`
+
+	// Disabled is set if the action cannot currently be applied, with the
+	// reason shown to the user.
+	Disabled *CodeActionDisabled `json:"disabled,omitempty"`
+
+	// Edit is the workspace edit the action performs.
+	Edit *WorkspaceEdit `json:"edit,omitempty"`
+
+	// Command is the command the action runs.
+	Command *Command `json:"command,omitempty"`
+
+	// Data is preserved by the client between a codeAction and a
+	// codeAction/resolve request.
+	Data LSPAny `json:"data,omitempty"`
+}
+
+// CodeActionDisabled describes why a code action is disabled.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#codeAction
+type CodeActionDisabled struct {
+	// Reason is a human-readable description of why the action is
+	// disabled.
+	Reason string `json:"reason"`
+}
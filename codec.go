@@ -0,0 +1,574 @@
+package golsptoolkit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Header field names defined by the base protocol.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#headerPart
+const (
+	HeaderContentLength = "Content-Length"
+	HeaderContentType   = "Content-Type"
+)
+
+// DefaultContentType is the Content-Type assumed when a message omits the header.
+const DefaultContentType = "application/vscode-jsonrpc; charset=utf-8"
+
+// Errors returned while reading framed messages.
+var (
+	ErrMissingContentLength = errors.New("golsptoolkit: missing Content-Length header")
+	ErrInvalidContentLength = errors.New("golsptoolkit: invalid Content-Length header")
+	ErrMalformedHeader      = errors.New("golsptoolkit: malformed header")
+	ErrUnsupportedCharset   = errors.New("golsptoolkit: unsupported charset")
+	ErrContentTooLarge      = errors.New("golsptoolkit: content length exceeds limit")
+	ErrDesync               = errors.New("golsptoolkit: stream out of sync")
+)
+
+// DefaultMaxContentLength is the limit, in bytes, NewMessageReader sets on
+// the content part of a message.
+const DefaultMaxContentLength = 64 << 20
+
+// maxContentLength is the largest Content-Length a MessageReader accepts
+// whatever its MaxContentLength. A larger value cannot be allocated, nor
+// trusted to be skipped, so it is reported as a desync.
+const maxContentLength = 1 << 30
+
+// HeaderMode controls how strictly a MessageReader parses header parts.
+type HeaderMode int
+
+const (
+	// HeaderModeStrict accepts only header parts that conform to the base
+	// protocol: CRLF-terminated lines, fields written exactly as
+	// "Name: value", no duplicate fields, and no fields other than
+	// Content-Length and Content-Type.
+	HeaderModeStrict HeaderMode = iota
+
+	// HeaderModeLenient additionally tolerates LF-only line terminators and
+	// stray whitespace around names and values, and records unknown fields
+	// in HeaderPart.Extra instead of rejecting them.
+	HeaderModeLenient
+)
+
+// MessageReader reads framed LSP messages from an underlying stream.
+//
+// A MessageReader is not safe for concurrent use.
+type MessageReader struct {
+	r *bufio.Reader
+
+	// MaxContentLength, if positive, is the largest content part the reader
+	// accepts. Larger messages are skipped and reported as
+	// ErrContentTooLarge without allocating their body. NewMessageReader
+	// sets it to DefaultMaxContentLength. Content-Length values beyond
+	// 1 GiB are rejected with ErrContentTooLarge and ErrDesync even
+	// without a limit.
+	MaxContentLength int
+
+	// Mode selects strict or lenient header parsing. The zero value is
+	// HeaderModeStrict.
+	Mode HeaderMode
+
+	// Recover enables resynchronization after ErrDesync. Instead of leaving
+	// the stream unusable, the next read skips forward to the next
+	// "Content-Length:" field and resumes from there. Bodies that turn out
+	// to contain the start of the following message, because their
+	// Content-Length was too large, are also detected and cut short.
+	Recover bool
+
+	resync bool
+
+	// pending holds bytes pushed back by trimOverrun. They are read before
+	// anything buffered in r.
+	pending []byte
+}
+
+// NewMessageReader returns a MessageReader reading from r.
+func NewMessageReader(r io.Reader) *MessageReader {
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &MessageReader{r: br, MaxContentLength: DefaultMaxContentLength}
+}
+
+// ReadHeader reads the header part of the next message, including the
+// terminating blank line. It returns io.EOF if the stream ends cleanly before
+// a new message starts.
+func (r *MessageReader) ReadHeader() (HeaderPart, error) {
+	var (
+		h         HeaderPart
+		hasLength bool
+		first     = true
+	)
+	for {
+		// ReadSlice returns a view into the bufio.Reader's own buffer, so
+		// header lines are parsed without allocating.
+		line, err := r.readLine()
+		if err != nil {
+			switch {
+			case err == bufio.ErrBufferFull:
+				err = fmt.Errorf("%w: header line too long", ErrMalformedHeader)
+			case err == io.EOF && (!first || len(line) > 0):
+				err = io.ErrUnexpectedEOF
+			}
+			return h, err
+		}
+		first = false
+
+		switch {
+		case bytes.HasSuffix(line, crlf):
+			line = line[:len(line)-2]
+		case r.Mode == HeaderModeLenient:
+			line = bytes.TrimSuffix(line[:len(line)-1], cr)
+		default:
+			return h, fmt.Errorf("%w: line not terminated by CRLF", ErrMalformedHeader)
+		}
+		if r.Mode == HeaderModeLenient {
+			line = bytes.TrimSpace(line)
+		}
+		if len(line) == 0 {
+			break
+		}
+
+		name, value, err := r.splitField(line)
+		if err != nil {
+			return h, err
+		}
+
+		switch {
+		case bytes.EqualFold(name, contentLengthName):
+			if hasLength && r.Mode == HeaderModeStrict {
+				return h, fmt.Errorf("%w: duplicate %s", ErrMalformedHeader, HeaderContentLength)
+			}
+			n, ok := parseContentLength(value)
+			if !ok {
+				return h, fmt.Errorf("%w: %q", ErrInvalidContentLength, value)
+			}
+			h.ContentLength = n
+			hasLength = true
+		case bytes.EqualFold(name, contentTypeName):
+			if h.ContentType != "" && r.Mode == HeaderModeStrict {
+				return h, fmt.Errorf("%w: duplicate %s", ErrMalformedHeader, HeaderContentType)
+			}
+			h.ContentType = string(value)
+		case r.Mode == HeaderModeLenient:
+			if h.Extra == nil {
+				h.Extra = make(map[string]string)
+			}
+			h.Extra[textproto.CanonicalMIMEHeaderKey(string(name))] = string(value)
+		default:
+			return h, fmt.Errorf("%w: unknown field %q", ErrMalformedHeader, name)
+		}
+	}
+
+	if !hasLength {
+		return h, ErrMissingContentLength
+	}
+
+	if h.ContentType == "" {
+		h.MediaType, h.Charset = defaultMediaType, "utf-8"
+		return h, nil
+	}
+	mediaType, charset, err := ParseContentType(h.ContentType)
+	if err != nil {
+		return h, err
+	}
+	h.MediaType, h.Charset = mediaType, charset
+	return h, nil
+}
+
+// readLine returns the next line, including its terminating LF, from the
+// pushed-back bytes and then the underlying stream. Like ReadSlice, the line
+// is only valid until the next read.
+func (r *MessageReader) readLine() ([]byte, error) {
+	if len(r.pending) == 0 {
+		return r.r.ReadSlice('\n')
+	}
+	if i := bytes.IndexByte(r.pending, '\n'); i >= 0 {
+		line := r.pending[:i+1]
+		r.pending = r.pending[i+1:]
+		return line, nil
+	}
+	// The line continues past the pushed-back bytes.
+	line := r.pending
+	r.pending = nil
+	rest, err := r.r.ReadSlice('\n')
+	return append(line, rest...), err
+}
+
+// splitField splits a header line into its name and value according to the
+// reader's mode.
+func (r *MessageReader) splitField(line []byte) (name, value []byte, err error) {
+	if r.Mode == HeaderModeLenient {
+		name, value, ok := bytes.Cut(line, colon)
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %q", ErrMalformedHeader, line)
+		}
+		return bytes.TrimSpace(name), bytes.TrimSpace(value), nil
+	}
+
+	name, value, ok := bytes.Cut(line, fieldSeparator)
+	if !ok || len(name) == 0 || len(value) == 0 ||
+		bytes.ContainsAny(name, " \t") ||
+		!bytes.Equal(value, bytes.TrimSpace(value)) {
+		return nil, nil, fmt.Errorf("%w: %q", ErrMalformedHeader, line)
+	}
+	return name, value, nil
+}
+
+var (
+	crlf              = []byte("\r\n")
+	cr                = []byte("\r")
+	colon             = []byte(":")
+	fieldSeparator    = []byte(": ")
+	contentLengthName = []byte(HeaderContentLength)
+	contentTypeName   = []byte(HeaderContentType)
+)
+
+// defaultMediaType is the media type of DefaultContentType.
+const defaultMediaType = "application/vscode-jsonrpc"
+
+// parseContentLength parses a non-negative decimal Content-Length value.
+func parseContentLength(b []byte) (int, bool) {
+	if len(b) == 0 || len(b) > 18 {
+		return 0, false
+	}
+	n := 0
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n, true
+}
+
+// ParseContentType parses the value of a Content-Type header into its media
+// type and charset. A missing charset defaults to utf-8, and "utf8" is
+// accepted for backwards compatibility as the specification requires. Any
+// other charset yields ErrUnsupportedCharset.
+func ParseContentType(v string) (mediaType, charset string, err error) {
+	mediaType, params, err := mime.ParseMediaType(v)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: invalid Content-Type %q: %v", ErrMalformedHeader, v, err)
+	}
+
+	charset = strings.ToLower(params["charset"])
+	switch charset {
+	case "", "utf-8", "utf8":
+		charset = "utf-8"
+	default:
+		return mediaType, charset, fmt.Errorf("%w: %q", ErrUnsupportedCharset, charset)
+	}
+	return mediaType, charset, nil
+}
+
+// ReadMessage reads the next message and returns its JSON content part.
+//
+// When a message is rejected with ErrContentTooLarge or ErrUnsupportedCharset
+// its content part is skipped, leaving the reader positioned at the start of
+// the next message. Garbage where a header part should start is reported as
+// ErrDesync, wrapping the underlying header error; see Recover. Any other
+// error leaves the stream in an undefined state.
+func (r *MessageReader) ReadMessage() ([]byte, error) {
+	h, err := r.readCheckedHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	body := make([]byte, h.ContentLength)
+	if err := r.readBody(body); err != nil {
+		return nil, err
+	}
+	return body[:r.trimOverrun(body)], nil
+}
+
+// ReadPooledMessage is like ReadMessage but reads the content part into a
+// pooled buffer. The caller must call Release once it no longer needs the
+// bytes, after which they must not be used. Servers handling a steady stream
+// of large notifications such as textDocument/didChange can use it to avoid
+// allocating a new body for every message.
+func (r *MessageReader) ReadPooledMessage() (*MessageBuffer, error) {
+	h, err := r.readCheckedHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := messageBufferPool.Get().(*MessageBuffer)
+	buf.grow(h.ContentLength)
+	if err := r.readBody(buf.b); err != nil {
+		buf.Release()
+		return nil, err
+	}
+	buf.b = buf.b[:r.trimOverrun(buf.b)]
+	return buf, nil
+}
+
+// readCheckedHeader reads the next header and applies the reader's limits,
+// skipping the content part of rejected messages.
+func (r *MessageReader) readCheckedHeader() (HeaderPart, error) {
+	if r.resync {
+		r.resync = false
+		if err := r.skipToHeader(); err != nil {
+			return HeaderPart{}, err
+		}
+	}
+
+	h, err := r.ReadHeader()
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrUnsupportedCharset):
+			if derr := r.discard(h.ContentLength); derr != nil {
+				return h, derr
+			}
+		case errors.Is(err, ErrMalformedHeader),
+			errors.Is(err, ErrInvalidContentLength),
+			errors.Is(err, ErrMissingContentLength):
+			r.resync = r.Recover
+			err = fmt.Errorf("%w: %w", ErrDesync, err)
+		}
+		return h, err
+	}
+	if h.ContentLength > maxContentLength {
+		r.resync = r.Recover
+		return h, fmt.Errorf("%w: %w: %d bytes", ErrDesync, ErrContentTooLarge, h.ContentLength)
+	}
+	if r.MaxContentLength > 0 && h.ContentLength > r.MaxContentLength {
+		if err := r.discard(h.ContentLength); err != nil {
+			return h, err
+		}
+		return h, fmt.Errorf("%w: %d > %d bytes", ErrContentTooLarge, h.ContentLength, r.MaxContentLength)
+	}
+	return h, nil
+}
+
+func (r *MessageReader) readBody(body []byte) error {
+	n := copy(body, r.pending)
+	r.pending = r.pending[n:]
+	if _, err := io.ReadFull(r.r, body[n:]); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	return nil
+}
+
+// contentLengthField is the text searched for when resynchronizing.
+var contentLengthField = []byte(HeaderContentLength + ":")
+
+// skipToHeader discards input up to the next occurrence of
+// "Content-Length:", compared case-insensitively.
+func (r *MessageReader) skipToHeader() error {
+	if len(r.pending) > 0 {
+		if i := indexFold(r.pending, contentLengthField); i >= 0 {
+			r.pending = r.pending[i:]
+			return nil
+		}
+		// The match may start in the tail of the pushed-back bytes and
+		// continue in the stream.
+		tail := r.pending[max(0, len(r.pending)-len(contentLengthField)+1):]
+		next, _ := r.r.Peek(len(contentLengthField) - 1)
+		if i := indexFold(append(bytes.Clone(tail), next...), contentLengthField); i >= 0 && i < len(tail) {
+			r.pending = tail[i:]
+			return nil
+		}
+		r.pending = nil
+	}
+	for {
+		if _, err := r.r.Peek(len(contentLengthField)); err != nil {
+			return err
+		}
+		buf, _ := r.r.Peek(r.r.Buffered())
+		if i := indexFold(buf, contentLengthField); i >= 0 {
+			_, err := r.r.Discard(i)
+			return err
+		}
+		// Keep the tail, which may hold the start of a split match.
+		if _, err := r.r.Discard(len(buf) - len(contentLengthField) + 1); err != nil {
+			return err
+		}
+	}
+}
+
+// trimOverrun detects, in Recover mode, a body whose Content-Length was too
+// large so that it swallowed the beginning of the next message: an invalid
+// body made of valid JSON followed by a header field. The remainder is pushed
+// back, to be read before the rest of the stream, and the length of the
+// actual content part returned.
+func (r *MessageReader) trimOverrun(body []byte) int {
+	if !r.Recover || json.Valid(body) {
+		return len(body)
+	}
+	i := indexFold(body, contentLengthField)
+	if i <= 0 || !json.Valid(body[:i]) {
+		return len(body)
+	}
+
+	r.pending = append(bytes.Clone(body[i:]), r.pending...)
+	return i
+}
+
+// indexFold returns the index of the first case-insensitive occurrence of sep
+// in s, or -1.
+func indexFold(s, sep []byte) int {
+	for i := 0; i+len(sep) <= len(s); i++ {
+		if bytes.EqualFold(s[i:i+len(sep)], sep) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *MessageReader) discard(n int) error {
+	m := min(n, len(r.pending))
+	r.pending = r.pending[m:]
+	if _, err := r.r.Discard(n - m); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	return nil
+}
+
+// ReadJSON reads the next message and decodes its content part into v.
+func (r *MessageReader) ReadJSON(v any) error {
+	body, err := r.ReadMessage()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+// maxPooledBuffer is the largest buffer returned to the pool; bigger buffers
+// are left to the garbage collector so one huge message does not pin memory.
+const maxPooledBuffer = 1 << 20
+
+var messageBufferPool = sync.Pool{
+	New: func() any { return new(MessageBuffer) },
+}
+
+// MessageBuffer holds the content part of a message read with
+// ReadPooledMessage.
+type MessageBuffer struct {
+	b []byte
+}
+
+// Bytes returns the content part. The slice is only valid until Release.
+func (m *MessageBuffer) Bytes() []byte {
+	return m.b
+}
+
+// Release returns the buffer to the pool.
+func (m *MessageBuffer) Release() {
+	if cap(m.b) > maxPooledBuffer {
+		return
+	}
+	m.b = m.b[:0]
+	messageBufferPool.Put(m)
+}
+
+func (m *MessageBuffer) grow(n int) {
+	if cap(m.b) < n {
+		m.b = make([]byte, n)
+	}
+	m.b = m.b[:n]
+}
+
+// FramingErrorResponse returns the error response a server should send when
+// ReadMessage fails with err, or nil if err is not a framing error. Messages
+// that were framed correctly but rejected (ErrContentTooLarge,
+// ErrUnsupportedCharset) are answered with InvalidRequest; unparsable headers
+// and bodies that fail to decompress with ParseError. Since the offending message was never decoded, the
+// response carries a null id.
+func FramingErrorResponse(err error) *ResponseMessage {
+	var code Integer
+	switch {
+	case errors.Is(err, ErrContentTooLarge), errors.Is(err, ErrUnsupportedCharset):
+		code = InvalidRequest
+	case errors.Is(err, ErrDesync),
+		errors.Is(err, ErrCorruptCompression),
+		errors.Is(err, ErrMalformedHeader),
+		errors.Is(err, ErrInvalidContentLength),
+		errors.Is(err, ErrMissingContentLength):
+		code = ParseError
+	default:
+		return nil
+	}
+	return &ResponseMessage{
+		AbstractMessage: AbstractMessage{JSONRPC: JSONRPCVersion},
+		Error:           &ResponseError{Code: code, Message: err.Error()},
+	}
+}
+
+// MessageWriter writes framed LSP messages to an underlying stream.
+//
+// A MessageWriter is safe for concurrent use; each message is written
+// atomically so concurrent writers never interleave their frames.
+type MessageWriter struct {
+	mu     sync.Mutex
+	w      *bufio.Writer
+	header [32]byte
+}
+
+// NewMessageWriter returns a MessageWriter writing to w.
+func NewMessageWriter(w io.Writer) *MessageWriter {
+	bw, ok := w.(*bufio.Writer)
+	if !ok {
+		bw = bufio.NewWriter(w)
+	}
+	return &MessageWriter{w: bw}
+}
+
+// WriteMessage writes body as a single framed message and flushes the
+// underlying stream.
+func (w *MessageWriter) WriteMessage(body []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	header := append(w.header[:0], HeaderContentLength...)
+	header = append(header, ": "...)
+	header = strconv.AppendInt(header, int64(len(body)), 10)
+	header = append(header, "\r\n\r\n"...)
+
+	if _, err := w.w.Write(header); err != nil {
+		return err
+	}
+	if _, err := w.w.Write(body); err != nil {
+		return err
+	}
+	return w.w.Flush()
+}
+
+var encodeBufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// WriteJSON encodes v as JSON and writes it as a single framed message. The
+// encoding buffer is pooled.
+func (w *MessageWriter) WriteJSON(v any) error {
+	buf := encodeBufferPool.Get().(*bytes.Buffer)
+	defer func() {
+		if buf.Cap() <= maxPooledBuffer {
+			buf.Reset()
+			encodeBufferPool.Put(buf)
+		}
+	}()
+
+	if err := json.NewEncoder(buf).Encode(v); err != nil {
+		return err
+	}
+	// Drop the newline appended by Encoder.
+	return w.WriteMessage(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+}
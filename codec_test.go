@@ -0,0 +1,84 @@
+package golsptoolkit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// loopReader endlessly repeats the same framed message.
+type loopReader struct {
+	data []byte
+	off  int
+}
+
+func (l *loopReader) Read(p []byte) (int, error) {
+	n := copy(p, l.data[l.off:])
+	l.off = (l.off + n) % len(l.data)
+	return n, nil
+}
+
+func benchmarkMessage() []byte {
+	body := fmt.Sprintf(`{"jsonrpc":"2.0","method":"textDocument/didChange","params":{"textDocument":{"uri":"file:///a.go","version":1},"contentChanges":[{"text":%q}]}}`, strings.Repeat("x", 4096))
+	return []byte(fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(body), body))
+}
+
+func BenchmarkReadMessage(b *testing.B) {
+	r := NewMessageReader(&loopReader{data: benchmarkMessage()})
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := r.ReadMessage(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadPooledMessage(b *testing.B) {
+	r := NewMessageReader(&loopReader{data: benchmarkMessage()})
+	b.ReportAllocs()
+	for b.Loop() {
+		buf, err := r.ReadPooledMessage()
+		if err != nil {
+			b.Fatal(err)
+		}
+		buf.Release()
+	}
+}
+
+func TestReadMessageContentTooLarge(t *testing.T) {
+	if got := NewMessageReader(strings.NewReader("")).MaxContentLength; got != DefaultMaxContentLength {
+		t.Fatalf("default MaxContentLength = %d, want %d", got, DefaultMaxContentLength)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		max   int
+		next  string
+	}{
+		{"unallocatable", "Content-Length: 999999999999999999\r\n\r\n{}", 0, ""},
+		{"skipped", "Content-Length: 10\r\n\r\n0123456789Content-Length: 2\r\n\r\n{}", 4, "{}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewMessageReader(strings.NewReader(tt.input))
+			r.MaxContentLength = tt.max
+			if _, err := r.ReadMessage(); !errors.Is(err, ErrContentTooLarge) {
+				t.Fatalf("ReadMessage error = %v, want ErrContentTooLarge", err)
+			}
+			if tt.next == "" {
+				return
+			}
+			body, err := r.ReadMessage()
+			if err != nil || string(body) != tt.next {
+				t.Fatalf("next ReadMessage = %q, %v, want %q", body, err, tt.next)
+			}
+		})
+	}
+
+	r := NewMessageReader(strings.NewReader("Content-Length: 999999999999999999\r\n\r\n{}"))
+	if _, err := r.ReadPooledMessage(); !errors.Is(err, ErrContentTooLarge) {
+		t.Fatalf("ReadPooledMessage error = %v, want ErrContentTooLarge", err)
+	}
+}
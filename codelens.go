@@ -0,0 +1,78 @@
+package golsptoolkit
+
+import "context"
+
+// Methods of code lenses.
+const (
+	MethodTextDocumentCodeLens     = "textDocument/codeLens"
+	MethodCodeLensResolve          = "codeLens/resolve"
+	MethodWorkspaceCodeLensRefresh = "workspace/codeLens/refresh"
+)
+
+// CodeLensOptions represents the code lens options a server announces in
+// ServerCapabilities.CodeLensProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#codeLensOptions
+type CodeLensOptions struct {
+	WorkDoneProgressOptions
+
+	// ResolveProvider reports whether the server computes the commands of
+	// code lenses with codeLens/resolve.
+	ResolveProvider bool `json:"resolveProvider,omitempty"`
+}
+
+// CodeLensRegistrationOptions are the options of a dynamic registration of
+// code lenses.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#codeLensRegistrationOptions
+type CodeLensRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	CodeLensOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (CodeLensRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentCodeLens
+}
+
+// CodeLensParams are the parameters of the textDocument/codeLens request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#codeLensParams
+type CodeLensParams struct {
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// TextDocument is the document to compute code lenses for.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+// CodeLens represents a command to show along with source text, such as the
+// number of references. A code lens without a command is unresolved; its
+// command is computed by codeLens/resolve.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#codeLens
+type CodeLens struct {
+	// Range is the range the code lens applies to. It should span a
+	// single line.
+	Range Range `json:"range"`
+
+	// Command is the command the code lens represents.
+	Command *Command `json:"command,omitempty"`
+
+	// Data is preserved by the client between a codeLens and a
+	// codeLens/resolve request.
+	Data LSPAny `json:"data,omitempty"`
+}
+
+// RefreshCodeLenses asks the client to refresh the code lenses of all
+// documents with the workspace/codeLens/refresh request, for example after a
+// configuration change. It does nothing if caps, the client's capabilities,
+// do not announce refreshSupport.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#codeLens_refresh
+func RefreshCodeLenses(ctx context.Context, conn *JSONRPCConn, caps *ClientCapabilities) error {
+	if caps == nil || caps.Workspace == nil || caps.Workspace.CodeLens == nil || !caps.Workspace.CodeLens.RefreshSupport {
+		return nil
+	}
+	return conn.Call(ctx, MethodWorkspaceCodeLensRefresh, nil, nil)
+}
@@ -0,0 +1,514 @@
+package golsptoolkit
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// Methods of code completion.
+const (
+	MethodTextDocumentCompletion = "textDocument/completion"
+	MethodCompletionItemResolve  = "completionItem/resolve"
+)
+
+// CompletionOptions represents the completion options a server announces in
+// ServerCapabilities.CompletionProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#completionOptions
+type CompletionOptions struct {
+	WorkDoneProgressOptions
+
+	// TriggerCharacters trigger completion automatically when typed, in
+	// addition to the characters the client completes identifiers on,
+	// such as "." in many languages.
+	TriggerCharacters []string `json:"triggerCharacters,omitempty"`
+
+	// AllCommitCharacters are the characters that commit any completion
+	// item. An item's own CommitCharacters override them.
+	AllCommitCharacters []string `json:"allCommitCharacters,omitempty"`
+
+	// ResolveProvider reports whether the server computes additional
+	// properties of completion items with completionItem/resolve.
+	ResolveProvider bool `json:"resolveProvider,omitempty"`
+
+	// CompletionItem describes the server's support for completion item
+	// properties.
+	CompletionItem *CompletionItemOptions `json:"completionItem,omitempty"`
+}
+
+// CompletionItemOptions describes the server's support for completion item
+// properties.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#completionOptions
+type CompletionItemOptions struct {
+	// LabelDetailsSupport reports whether the server provides label
+	// details in completion items.
+	LabelDetailsSupport bool `json:"labelDetailsSupport,omitempty"`
+}
+
+// CompletionRegistrationOptions are the options of a dynamic registration of
+// completion.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#completionRegistrationOptions
+type CompletionRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	CompletionOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (CompletionRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentCompletion
+}
+
+// CompletionParams are the parameters of the textDocument/completion
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#completionParams
+type CompletionParams struct {
+	TextDocumentPositionParams
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// Context describes how completion was triggered. It is only set if
+	// the client announced contextSupport.
+	Context *CompletionContext `json:"context,omitempty"`
+}
+
+// CompletionTriggerKind represents how completion was triggered.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#completionTriggerKind
+type CompletionTriggerKind UInteger
+
+const (
+	// CompletionTriggerKindInvoked is completion triggered by typing an
+	// identifier, by a keyboard shortcut, or through the API.
+	CompletionTriggerKindInvoked CompletionTriggerKind = 1
+
+	// CompletionTriggerKindTriggerCharacter is completion triggered by one
+	// of the server's trigger characters.
+	CompletionTriggerKindTriggerCharacter CompletionTriggerKind = 2
+
+	// CompletionTriggerKindTriggerForIncompleteCompletions is completion
+	// re-triggered because the previous list was incomplete.
+	CompletionTriggerKindTriggerForIncompleteCompletions CompletionTriggerKind = 3
+)
+
+var completionTriggerKindNames = enumNames{"CompletionTriggerKind", []string{
+	CompletionTriggerKindInvoked:                         "Invoked",
+	CompletionTriggerKindTriggerCharacter:                "TriggerCharacter",
+	CompletionTriggerKindTriggerForIncompleteCompletions: "TriggerForIncompleteCompletions",
+}}
+
+// String returns the name of k, such as "Invoked".
+func (k CompletionTriggerKind) String() string {
+	return completionTriggerKindNames.name(int64(k))
+}
+
+// IsValid reports whether k is one of the defined completion trigger kinds.
+func (k CompletionTriggerKind) IsValid() bool {
+	return completionTriggerKindNames.valid(int64(k))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Undefined values are rejected.
+func (k *CompletionTriggerKind) UnmarshalJSON(data []byte) error {
+	return decodeEnum(data, k, completionTriggerKindNames)
+}
+
+// CompletionContext describes how completion was triggered.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#completionContext
+type CompletionContext struct {
+	TriggerKind CompletionTriggerKind `json:"triggerKind"`
+
+	// TriggerCharacter is the character that triggered completion, if
+	// TriggerKind is CompletionTriggerKindTriggerCharacter.
+	TriggerCharacter string `json:"triggerCharacter,omitempty"`
+}
+
+// CompletionList represents a list of completion items. It is the result of
+// the textDocument/completion request, which may also answer with a plain
+// []CompletionItem.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#completionList
+type CompletionList struct {
+	// IsIncomplete reports whether typing further should recompute the
+	// list.
+	IsIncomplete bool `json:"isIncomplete"`
+
+	// ItemDefaults holds values applied to items that do not set them. It
+	// may only be used with properties the client lists in its
+	// completionList.itemDefaults capability.
+	ItemDefaults *CompletionItemDefaults `json:"itemDefaults,omitempty"`
+
+	// Items are the completion items.
+	Items []CompletionItem `json:"items"`
+}
+
+// MarshalJSON implements json.Marshaler. A nil Items is sent as an empty
+// array.
+func (l CompletionList) MarshalJSON() ([]byte, error) {
+	type list CompletionList
+	if l.Items == nil {
+		l.Items = []CompletionItem{}
+	}
+	return json.Marshal(list(l))
+}
+
+// CompletionItemDefaults holds the defaults of a CompletionList.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#completionList
+type CompletionItemDefaults struct {
+	// CommitCharacters is the default commit character set.
+	CommitCharacters []string `json:"commitCharacters,omitempty"`
+
+	// EditRange is the default range to replace. It may only be an
+	// EditRangeWithInsertReplace if the client supports insert and replace
+	// edits.
+	EditRange Or2[Range, EditRangeWithInsertReplace] `json:"editRange,omitzero"`
+
+	// InsertTextFormat is the default insert text format.
+	InsertTextFormat InsertTextFormat `json:"insertTextFormat,omitempty"`
+
+	// InsertTextMode is the default insert text mode.
+	InsertTextMode InsertTextMode `json:"insertTextMode,omitempty"`
+
+	// Data is the default data value.
+	Data LSPAny `json:"data,omitempty"`
+}
+
+// InsertTextFormat represents whether the insert text of a completion item
+// is plain text or a snippet.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#insertTextFormat
+type InsertTextFormat UInteger
+
+const (
+	// InsertTextFormatPlainText inserts the text as is.
+	InsertTextFormatPlainText InsertTextFormat = 1
+
+	// InsertTextFormatSnippet interprets the text as a snippet, with tab
+	// stops such as $1 and placeholders such as ${1:name}.
+	InsertTextFormatSnippet InsertTextFormat = 2
+)
+
+var insertTextFormatNames = enumNames{"InsertTextFormat", []string{
+	InsertTextFormatPlainText: "PlainText",
+	InsertTextFormatSnippet:   "Snippet",
+}}
+
+// String returns the name of f, such as "PlainText".
+func (f InsertTextFormat) String() string {
+	return insertTextFormatNames.name(int64(f))
+}
+
+// IsValid reports whether f is one of the defined insert text formats.
+func (f InsertTextFormat) IsValid() bool {
+	return insertTextFormatNames.valid(int64(f))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Undefined values are rejected.
+func (f *InsertTextFormat) UnmarshalJSON(data []byte) error {
+	return decodeEnum(data, f, insertTextFormatNames)
+}
+
+// CompletionItem represents a completion item.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#completionItem
+type CompletionItem struct {
+	// Label is shown in the list and, by default, is the text inserted
+	// when the item is selected.
+	Label string `json:"label"`
+
+	// LabelDetails adds details to the label.
+	LabelDetails *CompletionItemLabelDetails `json:"labelDetails,omitempty"`
+
+	// Kind is the kind of the item, used to pick an icon.
+	Kind CompletionItemKind `json:"kind,omitempty"`
+
+	// Tags tweak the rendering of the item.
+	Tags []CompletionItemTag `json:"tags,omitempty"`
+
+	// Detail is additional information, such as type or symbol
+	// information.
+	Detail string `json:"detail,omitempty"`
+
+	// Documentation is a doc comment for the item.
+	Documentation Or2[string, MarkupContent] `json:"documentation,omitzero"`
+
+	// Deprecated reports whether the item is deprecated.
+	//
+	// Deprecated: Use Tags instead.
+	Deprecated bool `json:"deprecated,omitempty"`
+
+	// Preselect selects the item when the list is shown. Only one item can
+	// be preselected.
+	Preselect bool `json:"preselect,omitempty"`
+
+	// SortText is used instead of Label to sort the items.
+	SortText string `json:"sortText,omitempty"`
+
+	// FilterText is used instead of Label to filter the items.
+	FilterText string `json:"filterText,omitempty"`
+
+	// InsertText is inserted instead of Label. It is ignored if TextEdit
+	// is set.
+	InsertText string `json:"insertText,omitempty"`
+
+	// InsertTextFormat is the format of InsertText and of the new text of
+	// TextEdit.
+	InsertTextFormat InsertTextFormat `json:"insertTextFormat,omitempty"`
+
+	// InsertTextMode is how whitespace and indentation are handled on
+	// insertion.
+	InsertTextMode InsertTextMode `json:"insertTextMode,omitempty"`
+
+	// TextEdit is the edit applied when the item is selected. Its ranges
+	// must be single-line and contain the request position.
+	TextEdit CompletionTextEdit `json:"textEdit,omitzero"`
+
+	// TextEditText is the new text of the default edit range of the list,
+	// used instead of Label.
+	TextEditText string `json:"textEditText,omitempty"`
+
+	// AdditionalTextEdits are applied along with the main edit, such as
+	// adding an import. They must not overlap the main edit.
+	AdditionalTextEdits []TextEdit `json:"additionalTextEdits,omitempty"`
+
+	// CommitCharacters select the item and are then typed, when the item
+	// is active.
+	CommitCharacters []string `json:"commitCharacters,omitempty"`
+
+	// Command is run after the item is inserted.
+	Command *Command `json:"command,omitempty"`
+
+	// Data is preserved by the client between a completion and a
+	// completionItem/resolve request.
+	Data LSPAny `json:"data,omitempty"`
+}
+
+// CompletionTextEdit is the edit of a completion item: a plain edit, or an
+// insert and replace edit, told apart by their properties.
+type CompletionTextEdit = Or2[TextEdit, InsertReplaceEdit]
+
+// NewCompletionTextEdit returns a completion edit replacing rng with
+// newText.
+func NewCompletionTextEdit(rng Range, newText string) CompletionTextEdit {
+	return NewOr2A[TextEdit, InsertReplaceEdit](TextEdit{Range: rng, NewText: newText})
+}
+
+// NewInsertReplaceCompletionTextEdit returns a completion edit inserting
+// newText over insert, or over replace if the user chooses to replace.
+func NewInsertReplaceCompletionTextEdit(insert, replace Range, newText string) CompletionTextEdit {
+	return NewOr2B[TextEdit](InsertReplaceEdit{NewText: newText, Insert: insert, Replace: replace})
+}
+
+// InsertReplaceEdit is a completion edit with distinct ranges for inserting
+// and replacing, between which the user chooses.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#insertReplaceEdit
+type InsertReplaceEdit struct {
+	// NewText is the text to insert.
+	NewText string `json:"newText"`
+
+	// Insert is the range replaced when inserting, typically ending at the
+	// cursor.
+	Insert Range `json:"insert"`
+
+	// Replace is the range replaced when replacing, typically spanning the
+	// whole word. It must contain Insert.
+	Replace Range `json:"replace"`
+}
+
+// EditRangeWithInsertReplace is the default edit range of a completion list
+// with distinct ranges for inserting and replacing.
+type EditRangeWithInsertReplace struct {
+	Insert  Range `json:"insert"`
+	Replace Range `json:"replace"`
+}
+
+// CompletionItemsForClient adapts items to the completion capabilities in
+// caps, modifying and returning it: insert and replace edits become plain
+// edits of their replace range if the client does not support them, and
+// insert text modes the client does not support are removed. caps may be
+// nil.
+func CompletionItemsForClient(items []CompletionItem, caps *ClientCapabilities) []CompletionItem {
+	var ic *CompletionItemClientCapabilities
+	if caps != nil && caps.TextDocument != nil && caps.TextDocument.Completion != nil {
+		ic = caps.TextDocument.Completion.CompletionItem
+	}
+	if ic == nil {
+		ic = &CompletionItemClientCapabilities{}
+	}
+
+	var modes []InsertTextMode
+	if ic.InsertTextModeSupport != nil {
+		modes = ic.InsertTextModeSupport.ValueSet
+	}
+	for i := range items {
+		item := &items[i]
+		if e, ok := item.TextEdit.B(); ok && !ic.InsertReplaceSupport {
+			item.TextEdit = NewCompletionTextEdit(e.Replace, e.NewText)
+		}
+		if item.InsertTextMode != 0 && !slices.Contains(modes, item.InsertTextMode) {
+			item.InsertTextMode = 0
+		}
+	}
+	return items
+}
+
+// CompletionItemLabelDetails adds details to the label of a completion
+// item.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#completionItemLabelDetails
+type CompletionItemLabelDetails struct {
+	// Detail is rendered right after the label, without spacing, such as
+	// a function signature.
+	Detail string `json:"detail,omitempty"`
+
+	// Description is rendered after Detail, less prominently, such as a
+	// fully qualified name or file path.
+	Description string `json:"description,omitempty"`
+}
+
+// CompletionItemKind represents the kind of a completion entry.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#completionItemKind
+type CompletionItemKind UInteger
+
+const (
+	CompletionItemKindText          CompletionItemKind = 1
+	CompletionItemKindMethod        CompletionItemKind = 2
+	CompletionItemKindFunction      CompletionItemKind = 3
+	CompletionItemKindConstructor   CompletionItemKind = 4
+	CompletionItemKindField         CompletionItemKind = 5
+	CompletionItemKindVariable      CompletionItemKind = 6
+	CompletionItemKindClass         CompletionItemKind = 7
+	CompletionItemKindInterface     CompletionItemKind = 8
+	CompletionItemKindModule        CompletionItemKind = 9
+	CompletionItemKindProperty      CompletionItemKind = 10
+	CompletionItemKindUnit          CompletionItemKind = 11
+	CompletionItemKindValue         CompletionItemKind = 12
+	CompletionItemKindEnum          CompletionItemKind = 13
+	CompletionItemKindKeyword       CompletionItemKind = 14
+	CompletionItemKindSnippet       CompletionItemKind = 15
+	CompletionItemKindColor         CompletionItemKind = 16
+	CompletionItemKindFile          CompletionItemKind = 17
+	CompletionItemKindReference     CompletionItemKind = 18
+	CompletionItemKindFolder        CompletionItemKind = 19
+	CompletionItemKindEnumMember    CompletionItemKind = 20
+	CompletionItemKindConstant      CompletionItemKind = 21
+	CompletionItemKindStruct        CompletionItemKind = 22
+	CompletionItemKindEvent         CompletionItemKind = 23
+	CompletionItemKindOperator      CompletionItemKind = 24
+	CompletionItemKindTypeParameter CompletionItemKind = 25
+)
+
+var completionItemKindNames = enumNames{"CompletionItemKind", []string{
+	CompletionItemKindText:          "Text",
+	CompletionItemKindMethod:        "Method",
+	CompletionItemKindFunction:      "Function",
+	CompletionItemKindConstructor:   "Constructor",
+	CompletionItemKindField:         "Field",
+	CompletionItemKindVariable:      "Variable",
+	CompletionItemKindClass:         "Class",
+	CompletionItemKindInterface:     "Interface",
+	CompletionItemKindModule:        "Module",
+	CompletionItemKindProperty:      "Property",
+	CompletionItemKindUnit:          "Unit",
+	CompletionItemKindValue:         "Value",
+	CompletionItemKindEnum:          "Enum",
+	CompletionItemKindKeyword:       "Keyword",
+	CompletionItemKindSnippet:       "Snippet",
+	CompletionItemKindColor:         "Color",
+	CompletionItemKindFile:          "File",
+	CompletionItemKindReference:     "Reference",
+	CompletionItemKindFolder:        "Folder",
+	CompletionItemKindEnumMember:    "EnumMember",
+	CompletionItemKindConstant:      "Constant",
+	CompletionItemKindStruct:        "Struct",
+	CompletionItemKindEvent:         "Event",
+	CompletionItemKindOperator:      "Operator",
+	CompletionItemKindTypeParameter: "TypeParameter",
+}}
+
+// String returns the name of k, such as "Text".
+func (k CompletionItemKind) String() string {
+	return completionItemKindNames.name(int64(k))
+}
+
+// IsValid reports whether k is one of the defined completion item kinds.
+func (k CompletionItemKind) IsValid() bool {
+	return completionItemKindNames.valid(int64(k))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Undefined values are rejected.
+func (k *CompletionItemKind) UnmarshalJSON(data []byte) error {
+	return decodeEnum(data, k, completionItemKindNames)
+}
+
+// CompletionItemTag represents an extra annotation that tweaks the rendering
+// of a completion item.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#completionItemTag
+type CompletionItemTag UInteger
+
+const (
+	// CompletionItemTagDeprecated renders the item as obsolete, usually
+	// with a strike-out.
+	CompletionItemTagDeprecated CompletionItemTag = 1
+)
+
+var completionItemTagNames = enumNames{"CompletionItemTag", []string{
+	CompletionItemTagDeprecated: "Deprecated",
+}}
+
+// String returns the name of t, such as "Deprecated".
+func (t CompletionItemTag) String() string {
+	return completionItemTagNames.name(int64(t))
+}
+
+// IsValid reports whether t is one of the defined completion item tags.
+func (t CompletionItemTag) IsValid() bool {
+	return completionItemTagNames.valid(int64(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Undefined values are rejected.
+func (t *CompletionItemTag) UnmarshalJSON(data []byte) error {
+	return decodeEnum(data, t, completionItemTagNames)
+}
+
+// InsertTextMode represents how whitespace and indentation are handled when
+// a completion item is inserted.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#insertTextMode
+type InsertTextMode UInteger
+
+const (
+	// InsertTextModeAsIs inserts the text unchanged: the client does not
+	// adjust leading whitespace of new lines.
+	InsertTextModeAsIs InsertTextMode = 1
+
+	// InsertTextModeAdjustIndentation adjusts the indentation of new lines
+	// to the line the item is inserted on.
+	InsertTextModeAdjustIndentation InsertTextMode = 2
+)
+
+var insertTextModeNames = enumNames{"InsertTextMode", []string{
+	InsertTextModeAsIs:              "AsIs",
+	InsertTextModeAdjustIndentation: "AdjustIndentation",
+}}
+
+// String returns the name of m, such as "AsIs".
+func (m InsertTextMode) String() string {
+	return insertTextModeNames.name(int64(m))
+}
+
+// IsValid reports whether m is one of the defined insert text modes.
+func (m InsertTextMode) IsValid() bool {
+	return insertTextModeNames.valid(int64(m))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Undefined values are rejected.
+func (m *InsertTextMode) UnmarshalJSON(data []byte) error {
+	return decodeEnum(data, m, insertTextModeNames)
+}
@@ -0,0 +1,182 @@
+package golsptoolkit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// CompressionCapability is the key under which peers list the compression
+// algorithms they support, in order of preference, inside the experimental
+// section of their capabilities:
+//
+//	"experimental": {"golsptoolkit.compression": ["gzip"]}
+const CompressionCapability = "golsptoolkit.compression"
+
+// CompressionGzip names gzip compression, currently the only supported
+// algorithm.
+const CompressionGzip = "gzip"
+
+// DefaultCompressionThreshold is the default minimum body size, in bytes,
+// worth compressing.
+const DefaultCompressionThreshold = 1024
+
+// DefaultMaxDecompressedSize is the default bound, in bytes, on the size of
+// a decompressed message.
+const DefaultMaxDecompressedSize = 64 << 20
+
+// ErrCorruptCompression is returned by CompressConn.ReadMessage for a
+// compressed body that cannot be decompressed. Only that message is lost;
+// the connection remains usable.
+var ErrCorruptCompression = errors.New("golsptoolkit: corrupt compressed body")
+
+// AdvertiseCompression adds the compression algorithms algs to the
+// experimental capabilities object experimental, allocating it if nil.
+func AdvertiseCompression(experimental LSPObject, algs ...string) LSPObject {
+	if experimental == nil {
+		experimental = make(LSPObject)
+	}
+	list := make(LSPArray, len(algs))
+	for i, alg := range algs {
+		list[i] = alg
+	}
+	experimental[CompressionCapability] = list
+	return experimental
+}
+
+// NegotiateCompression returns the first algorithm of local that the peer
+// also advertised in its experimental capabilities, or "" if there is none.
+func NegotiateCompression(local []string, experimental LSPAny) string {
+	obj, ok := experimental.(LSPObject)
+	if !ok {
+		return ""
+	}
+	remote, ok := obj[CompressionCapability].(LSPArray)
+	if !ok {
+		return ""
+	}
+	for _, alg := range local {
+		for _, r := range remote {
+			if r == alg {
+				return alg
+			}
+		}
+	}
+	return ""
+}
+
+// CompressConn wraps a Conn with transparent body compression.
+//
+// Outgoing messages are compressed once compression has been switched on
+// with SetCompression, typically after the initialize exchange negotiated it.
+// Incoming messages are decompressed whenever they carry the gzip magic
+// number, which can never start a JSON text, so the two sides may switch
+// independently without coordinating the exact message.
+type CompressConn struct {
+	Conn
+
+	// Threshold is the minimum body size that gets compressed. Zero means
+	// DefaultCompressionThreshold.
+	Threshold int
+
+	// MaxDecompressedSize is the largest body, once decompressed, that
+	// ReadMessage accepts; larger ones are reported as ErrContentTooLarge,
+	// so a small compressed message cannot expand without bound. Zero
+	// means the MaxContentLength of the wrapped connection's reader if it
+	// is a *StreamConn with a limit set, and DefaultMaxDecompressedSize
+	// otherwise.
+	MaxDecompressedSize int
+
+	mu  sync.RWMutex
+	alg string
+}
+
+// NewCompressConn returns a CompressConn wrapping c with compression of
+// outgoing messages switched off.
+func NewCompressConn(c Conn) *CompressConn {
+	return &CompressConn{Conn: c}
+}
+
+// SetCompression switches compression of outgoing messages to alg, or off if
+// alg is empty.
+func (c *CompressConn) SetCompression(alg string) error {
+	if alg != "" && alg != CompressionGzip {
+		return fmt.Errorf("golsptoolkit: unsupported compression %q", alg)
+	}
+	c.mu.Lock()
+	c.alg = alg
+	c.mu.Unlock()
+	return nil
+}
+
+// ReadMessage reads the next message, decompressing it if needed.
+func (c *CompressConn) ReadMessage(ctx context.Context) ([]byte, error) {
+	body, err := c.Conn.ReadMessage(ctx)
+	if err != nil || !isGzip(body) {
+		return body, err
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorruptCompression, err)
+	}
+	limit := c.maxDecompressedSize()
+	body, err = io.ReadAll(io.LimitReader(zr, int64(limit)+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorruptCompression, err)
+	}
+	if len(body) > limit {
+		return nil, fmt.Errorf("%w: decompressed body exceeds %d bytes", ErrContentTooLarge, limit)
+	}
+	return body, nil
+}
+
+func (c *CompressConn) maxDecompressedSize() int {
+	if c.MaxDecompressedSize > 0 {
+		return c.MaxDecompressedSize
+	}
+	if sc, ok := c.Conn.(*StreamConn); ok && sc.Reader().MaxContentLength > 0 {
+		return sc.Reader().MaxContentLength
+	}
+	return DefaultMaxDecompressedSize
+}
+
+// WriteMessage writes body, compressed if compression is on and body is
+// large enough.
+func (c *CompressConn) WriteMessage(ctx context.Context, body []byte) error {
+	c.mu.RLock()
+	alg := c.alg
+	c.mu.RUnlock()
+
+	threshold := c.Threshold
+	if threshold <= 0 {
+		threshold = DefaultCompressionThreshold
+	}
+	if alg == "" || len(body) < threshold {
+		return c.Conn.WriteMessage(ctx, body)
+	}
+
+	var buf bytes.Buffer
+	zw := gzipWriterPool.Get().(*gzip.Writer)
+	defer gzipWriterPool.Put(zw)
+	zw.Reset(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return c.Conn.WriteMessage(ctx, buf.Bytes())
+}
+
+var gzipWriterPool = sync.Pool{
+	New: func() any { return gzip.NewWriter(nil) },
+}
+
+func isGzip(body []byte) bool {
+	return len(body) >= 2 && body[0] == 0x1f && body[1] == 0x8b
+}
@@ -0,0 +1,75 @@
+package golsptoolkit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCompressConnCorruptBody(t *testing.T) {
+	client, server := Pipe()
+	conn := NewCompressConn(server)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// The gzip magic number followed by garbage, then a plain message.
+	if err := client.WriteMessage(ctx, []byte("\x1f\x8bcorrupt")); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.WriteMessage(ctx, []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := conn.ReadMessage(ctx)
+	if !errors.Is(err, ErrCorruptCompression) {
+		t.Fatalf("ReadMessage = %v, want %v", err, ErrCorruptCompression)
+	}
+	if resp := FramingErrorResponse(err); resp == nil || resp.Error.Code != ParseError {
+		t.Fatalf("FramingErrorResponse = %v, want a ParseError response", resp)
+	}
+	body, err := conn.ReadMessage(ctx)
+	if err != nil || string(body) != `{}` {
+		t.Fatalf("next ReadMessage = %s, %v, want {}", body, err)
+	}
+}
+
+func TestJSONRPCConnSurvivesCorruptBody(t *testing.T) {
+	client, server := Pipe()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	srv := NewJSONRPCConn(NewCompressConn(server), HandlerFunc(func(ctx context.Context, reply Replier, req *Request) {
+		reply(ctx, "ok", nil)
+	}))
+	done := make(chan error, 1)
+	go func() { done <- srv.Run(ctx) }()
+	defer srv.Close()
+
+	if err := client.WriteMessage(ctx, []byte("\x1f\x8b\x08\x00truncated")); err != nil {
+		t.Fatal(err)
+	}
+	body, err := client.ReadMessage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const parseError = `{"jsonrpc":"2.0","id":null,"error":{"code":-32700,`
+	if len(body) < len(parseError) || string(body[:len(parseError)]) != parseError {
+		t.Fatalf("answer to corrupt body = %s, want a ParseError", body)
+	}
+
+	if err := client.WriteMessage(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"m"}`)); err != nil {
+		t.Fatal(err)
+	}
+	body, err = client.ReadMessage(ctx)
+	if err != nil || string(body) != `{"jsonrpc":"2.0","id":1,"result":"ok"}` {
+		t.Fatalf("answer to request = %s, %v", body, err)
+	}
+	select {
+	case err := <-done:
+		t.Fatalf("Run returned %v after a corrupt body", err)
+	default:
+	}
+}
@@ -0,0 +1,99 @@
+package golsptoolkit
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// Methods of workspace configuration.
+const (
+	MethodWorkspaceConfiguration          = "workspace/configuration"
+	MethodWorkspaceDidChangeConfiguration = "workspace/didChangeConfiguration"
+)
+
+// ConfigurationParams are the parameters of the workspace/configuration
+// request. The result holds one value per item, in order.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#configurationParams
+type ConfigurationParams struct {
+	Items []ConfigurationItem `json:"items"`
+}
+
+// ConfigurationItem identifies a configuration section to fetch.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#configurationItem
+type ConfigurationItem struct {
+	// ScopeURI is the scope to get the configuration for, such as a
+	// document or folder.
+	ScopeURI URI `json:"scopeUri,omitempty"`
+
+	// Section is the configuration section, such as "gopls".
+	Section string `json:"section,omitempty"`
+}
+
+// DidChangeConfigurationParams are the parameters of the
+// workspace/didChangeConfiguration notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#didChangeConfigurationParams
+type DidChangeConfigurationParams struct {
+	// Settings are the changed settings. Many clients send null, leaving
+	// the server to fetch what it needs with workspace/configuration.
+	Settings LSPAny `json:"settings"`
+}
+
+// Configuration fetches the configuration identified by item from the
+// client with the workspace/configuration request, decoded into T. If the
+// client has no such configuration, it returns the zero T.
+//
+//	type settings struct {
+//		BuildFlags []string `json:"buildFlags"`
+//	}
+//	cfg, err := golsptoolkit.Configuration[settings](ctx, conn, golsptoolkit.ConfigurationItem{Section: "gopls"})
+func Configuration[T any](ctx context.Context, conn *JSONRPCConn, item ConfigurationItem) (T, error) {
+	var v T
+	items, err := Call[[]json.RawMessage](ctx, conn, MethodWorkspaceConfiguration, &ConfigurationParams{Items: []ConfigurationItem{item}})
+	if err != nil || len(items) == 0 {
+		return v, err
+	}
+	err = json.Unmarshal(items[0], &v)
+	return v, err
+}
+
+// ConfigurationSection decodes the section of settings, as received in
+// DidChangeConfigurationParams, into T. section is a dotted path such as
+// "gopls.ui"; if it is empty, all of settings is decoded. If settings does
+// not contain the section, it returns the zero T.
+func ConfigurationSection[T any](settings LSPAny, section string) (T, error) {
+	var v T
+	if raw, ok := settings.(json.RawMessage); ok {
+		settings = nil
+		if err := json.Unmarshal(raw, &settings); err != nil {
+			return v, err
+		}
+	}
+	if section != "" {
+		for _, key := range strings.Split(section, ".") {
+			m, ok := settings.(map[string]any)
+			if !ok {
+				return v, nil
+			}
+			if settings, ok = m[key]; !ok {
+				return v, nil
+			}
+		}
+	}
+	if settings == nil {
+		return v, nil
+	}
+	if s, ok := settings.(T); ok {
+		return s, nil
+	}
+
+	raw, err := json.Marshal(settings)
+	if err != nil {
+		return v, err
+	}
+	err = json.Unmarshal(raw, &v)
+	return v, err
+}
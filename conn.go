@@ -0,0 +1,111 @@
+package golsptoolkit
+
+import (
+	"context"
+	"time"
+)
+
+// Conn is a bidirectional stream of LSP messages. Each message is exchanged
+// as its JSON content part; framing is the transport's concern.
+//
+// Every transport in this package implements Conn, so dispatchers,
+// middleware and recorders can be written once against it. Implementations
+// must allow WriteMessage to be called concurrently with ReadMessage and with
+// other writes; ReadMessage is only ever called from one goroutine at a time.
+type Conn interface {
+	// ReadMessage returns the content part of the next incoming message.
+	// It returns io.EOF once the peer has closed the connection, and the
+	// context's error if ctx is done first. A read abandoned this way is not
+	// lost: the message is returned by the next call.
+	ReadMessage(ctx context.Context) ([]byte, error)
+
+	// WriteMessage sends body as a single message. If ctx is done before the
+	// message is fully written the connection may be left unusable and
+	// should be closed.
+	WriteMessage(ctx context.Context, body []byte) error
+
+	// Close closes the connection. Pending and later reads and writes fail.
+	Close() error
+}
+
+var (
+	_ Conn = (*StreamConn)(nil)
+	_ Conn = (*WebSocketConn)(nil)
+	_ Conn = (*ReconnectingConn)(nil)
+	_ Conn = (*CompressConn)(nil)
+	_ Conn = (*QueueConn)(nil)
+)
+
+type readResult struct {
+	body []byte
+	err  error
+}
+
+// contextReader lets a blocking message read honor context cancellation
+// without ever abandoning a partially read message: a read interrupted by its
+// context keeps running in the background, and its result is returned by the
+// next call.
+type contextReader struct {
+	read    func() ([]byte, error)
+	pending chan readResult
+}
+
+func (r *contextReader) ReadMessage(ctx context.Context) ([]byte, error) {
+	if r.pending == nil {
+		if ctx.Done() == nil {
+			return r.read()
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		ch := make(chan readResult, 1)
+		go func() {
+			body, err := r.read()
+			ch <- readResult{body, err}
+		}()
+		r.pending = ch
+	}
+
+	select {
+	case res := <-r.pending:
+		r.pending = nil
+		return res.body, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// writeDeadliner is implemented by streams that support write deadlines,
+// such as net.Conn and pollable os.File values.
+type writeDeadliner interface {
+	SetWriteDeadline(t time.Time) error
+}
+
+// withWriteDeadline runs write with the deadline and cancellation of ctx
+// applied to d. It falls back to checking ctx before writing when d is nil or
+// rejects deadlines.
+func withWriteDeadline(ctx context.Context, d writeDeadliner, write func() error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if d == nil || ctx.Done() == nil {
+		return write()
+	}
+
+	deadline, _ := ctx.Deadline()
+	if d.SetWriteDeadline(deadline) != nil {
+		return write()
+	}
+	stop := context.AfterFunc(ctx, func() {
+		d.SetWriteDeadline(aLongTimeAgo)
+	})
+	err := write()
+	if !stop() && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	d.SetWriteDeadline(time.Time{})
+	return err
+}
+
+// aLongTimeAgo is a deadline in the past, used to interrupt blocked I/O.
+var aLongTimeAgo = time.Unix(1, 0)
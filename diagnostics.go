@@ -0,0 +1,534 @@
+package golsptoolkit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Methods of push and pull diagnostics.
+const (
+	MethodTextDocumentPublishDiagnostics = "textDocument/publishDiagnostics"
+	MethodTextDocumentDiagnostic         = "textDocument/diagnostic"
+	MethodWorkspaceDiagnostic            = "workspace/diagnostic"
+	MethodWorkspaceDiagnosticRefresh     = "workspace/diagnostic/refresh"
+)
+
+// DiagnosticOptions represents the pull diagnostics options a server
+// announces in ServerCapabilities.DiagnosticProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#diagnosticOptions
+type DiagnosticOptions struct {
+	WorkDoneProgressOptions
+
+	// Identifier identifies the diagnostics of the server, to tell them
+	// apart from those of other servers.
+	Identifier string `json:"identifier,omitempty"`
+
+	// InterFileDependencies reports whether a change in one document can
+	// change the diagnostics of others, such as in most programming
+	// languages.
+	InterFileDependencies bool `json:"interFileDependencies"`
+
+	// WorkspaceDiagnostics reports whether the server answers
+	// workspace/diagnostic requests.
+	WorkspaceDiagnostics bool `json:"workspaceDiagnostics"`
+}
+
+// DiagnosticRegistrationOptions are the options of a dynamic or static
+// registration of pull diagnostics.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#diagnosticRegistrationOptions
+type DiagnosticRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	DiagnosticOptions
+	StaticRegistrationOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (DiagnosticRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentDiagnostic
+}
+
+// DiagnosticSeverity represents the severity of a diagnostic.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#diagnosticSeverity
+type DiagnosticSeverity UInteger
+
+const (
+	DiagnosticSeverityError       DiagnosticSeverity = 1
+	DiagnosticSeverityWarning     DiagnosticSeverity = 2
+	DiagnosticSeverityInformation DiagnosticSeverity = 3
+	DiagnosticSeverityHint        DiagnosticSeverity = 4
+)
+
+var diagnosticSeverityNames = enumNames{"DiagnosticSeverity", []string{
+	DiagnosticSeverityError:       "Error",
+	DiagnosticSeverityWarning:     "Warning",
+	DiagnosticSeverityInformation: "Information",
+	DiagnosticSeverityHint:        "Hint",
+}}
+
+// String returns the name of s, such as "Error".
+func (s DiagnosticSeverity) String() string {
+	return diagnosticSeverityNames.name(int64(s))
+}
+
+// IsValid reports whether s is one of the defined diagnostic severities.
+func (s DiagnosticSeverity) IsValid() bool {
+	return diagnosticSeverityNames.valid(int64(s))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Undefined values are rejected.
+func (s *DiagnosticSeverity) UnmarshalJSON(data []byte) error {
+	return decodeEnum(data, s, diagnosticSeverityNames)
+}
+
+// DiagnosticTag represents an extra annotation that tweaks the rendering of
+// a diagnostic.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#diagnosticTag
+type DiagnosticTag UInteger
+
+const (
+	// DiagnosticTagUnnecessary marks unused or unnecessary code. Clients
+	// may render it faded out instead of with an error squiggle.
+	DiagnosticTagUnnecessary DiagnosticTag = 1
+
+	// DiagnosticTagDeprecated marks deprecated or obsolete code. Clients
+	// may render it with a strike-through.
+	DiagnosticTagDeprecated DiagnosticTag = 2
+)
+
+var diagnosticTagNames = enumNames{"DiagnosticTag", []string{
+	DiagnosticTagUnnecessary: "Unnecessary",
+	DiagnosticTagDeprecated:  "Deprecated",
+}}
+
+// String returns the name of t, such as "Unnecessary".
+func (t DiagnosticTag) String() string {
+	return diagnosticTagNames.name(int64(t))
+}
+
+// IsValid reports whether t is one of the defined diagnostic tags.
+func (t DiagnosticTag) IsValid() bool {
+	return diagnosticTagNames.valid(int64(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Undefined values are rejected.
+func (t *DiagnosticTag) UnmarshalJSON(data []byte) error {
+	return decodeEnum(data, t, diagnosticTagNames)
+}
+
+// DiagnosticCode is the code of a diagnostic, which may be an integer or a
+// string. Like ID it keeps the representation it was created or decoded
+// with.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#diagnostic
+type DiagnosticCode struct {
+	intOrString
+}
+
+// NewIntDiagnosticCode returns an integer diagnostic code.
+func NewIntDiagnosticCode(n int64) *DiagnosticCode {
+	return &DiagnosticCode{intOrString{num: n}}
+}
+
+// NewStringDiagnosticCode returns a string diagnostic code.
+func NewStringDiagnosticCode(s string) *DiagnosticCode {
+	return &DiagnosticCode{intOrString{str: s, isString: true}}
+}
+
+// Diagnostic represents a diagnostic, such as a compiler error or warning.
+// Diagnostics are only valid in the scope of a resource.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#diagnostic
+type Diagnostic struct {
+	// Range is the range the diagnostic applies to.
+	Range Range `json:"range"`
+
+	// Severity is the severity of the diagnostic. If omitted, the client
+	// decides how to interpret it.
+	Severity DiagnosticSeverity `json:"severity,omitempty"`
+
+	// Code is the diagnostic's code, which might appear in the user
+	// interface. It is omitted when nil; an empty string or a zero integer
+	// is sent as is.
+	Code *DiagnosticCode `json:"code,omitempty"`
+
+	// CodeDescription describes the error code.
+	CodeDescription *CodeDescription `json:"codeDescription,omitempty"`
+
+	// Source is a human-readable string describing the source of the
+	// diagnostic, such as "vet".
+	Source string `json:"source,omitempty"`
+
+	// Message is the diagnostic's message.
+	Message string `json:"message"`
+
+	// Tags are additional metadata about the diagnostic.
+	Tags []DiagnosticTag `json:"tags,omitempty"`
+
+	// RelatedInformation lists related locations, such as the other
+	// declarations of a symbol declared twice.
+	RelatedInformation []DiagnosticRelatedInformation `json:"relatedInformation,omitempty"`
+
+	// Data is preserved by the client between a publishDiagnostics
+	// notification and a codeAction request.
+	Data LSPAny `json:"data,omitempty"`
+}
+
+// DiagnosticRelatedInformation represents a related message and source code
+// location for a diagnostic.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#diagnosticRelatedInformation
+type DiagnosticRelatedInformation struct {
+	Location Location `json:"location"`
+	Message  string   `json:"message"`
+}
+
+// CodeDescription represents a description of a diagnostic code.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#codeDescription
+type CodeDescription struct {
+	// Href is a URI to open with more information about the code.
+	Href URI `json:"href"`
+}
+
+// PublishDiagnosticsParams are the parameters of the
+// textDocument/publishDiagnostics notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_publishDiagnostics
+type PublishDiagnosticsParams struct {
+	// URI is the document the diagnostics belong to.
+	URI DocumentURI `json:"uri"`
+
+	// Version is the version of the document the diagnostics were computed
+	// for.
+	Version *Integer `json:"version,omitempty"`
+
+	// Diagnostics replaces all diagnostics previously published for the
+	// document. An empty or nil slice clears them.
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// MarshalJSON implements json.Marshaler. A nil Diagnostics is sent as an
+// empty array, since clients reject null.
+func (p PublishDiagnosticsParams) MarshalJSON() ([]byte, error) {
+	type params PublishDiagnosticsParams
+	p.Diagnostics = nonNilDiagnostics(p.Diagnostics)
+	return json.Marshal(params(p))
+}
+
+// DocumentDiagnosticParams are the parameters of the textDocument/diagnostic
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_pullDiagnostics
+type DocumentDiagnosticParams struct {
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// TextDocument is the document to compute diagnostics for.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+
+	// Identifier is the identifier the server registered the diagnostic
+	// provider with.
+	Identifier string `json:"identifier,omitempty"`
+
+	// PreviousResultID is the result id of the last report the client
+	// received for the document.
+	PreviousResultID string `json:"previousResultId,omitempty"`
+}
+
+// DocumentDiagnosticReportKind represents the kind of a document diagnostic
+// report.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentDiagnosticReportKind
+type DocumentDiagnosticReportKind = string
+
+const (
+	// DocumentDiagnosticReportKindFull is a report with a full set of
+	// diagnostics.
+	DocumentDiagnosticReportKindFull DocumentDiagnosticReportKind = "full"
+
+	// DocumentDiagnosticReportKindUnchanged is a report indicating that
+	// nothing changed since the report with the same result id.
+	DocumentDiagnosticReportKindUnchanged DocumentDiagnosticReportKind = "unchanged"
+)
+
+// DocumentDiagnosticReport is the result of the textDocument/diagnostic
+// request. It holds a full report, with Items, or an unchanged report,
+// which only carries the ResultID of the previous report. Use
+// NewFullDocumentDiagnosticReport and NewUnchangedDocumentDiagnosticReport
+// to create one.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentDiagnosticReport
+type DocumentDiagnosticReport struct {
+	Kind DocumentDiagnosticReportKind `json:"kind"`
+
+	// ResultID identifies the report. A later request may send it as
+	// PreviousResultID to get an unchanged report if nothing changed. It
+	// is required for unchanged reports.
+	ResultID string `json:"resultId,omitempty"`
+
+	// Items are the diagnostics of a full report.
+	Items []Diagnostic `json:"items,omitempty"`
+
+	// RelatedDocuments holds the reports of other documents whose
+	// diagnostics are affected by the document, such as the header files
+	// of a C source file. The reports must not have related documents
+	// themselves.
+	RelatedDocuments map[DocumentURI]DocumentDiagnosticReport `json:"relatedDocuments,omitempty"`
+}
+
+// NewFullDocumentDiagnosticReport returns a full report of items, identified
+// by resultID if it is not empty.
+func NewFullDocumentDiagnosticReport(resultID string, items []Diagnostic) DocumentDiagnosticReport {
+	return DocumentDiagnosticReport{Kind: DocumentDiagnosticReportKindFull, ResultID: resultID, Items: items}
+}
+
+// NewUnchangedDocumentDiagnosticReport returns a report indicating that the
+// diagnostics of the report identified by resultID are still valid.
+func NewUnchangedDocumentDiagnosticReport(resultID string) DocumentDiagnosticReport {
+	return DocumentDiagnosticReport{Kind: DocumentDiagnosticReportKindUnchanged, ResultID: resultID}
+}
+
+// MarshalJSON implements json.Marshaler. A full report always carries the
+// items member, and an unchanged report never does.
+func (r DocumentDiagnosticReport) MarshalJSON() ([]byte, error) {
+	type report DocumentDiagnosticReport
+	if r.Kind == DocumentDiagnosticReportKindFull {
+		return json.Marshal(struct {
+			report
+			Items []Diagnostic `json:"items"`
+		}{report(r), nonNilDiagnostics(r.Items)})
+	}
+	r.Items = nil
+	return json.Marshal(report(r))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The kind property must name a
+// full report with items or an unchanged report with a result id.
+func (r *DocumentDiagnosticReport) UnmarshalJSON(data []byte) error {
+	type report DocumentDiagnosticReport
+	var v struct {
+		report
+		Items *[]Diagnostic `json:"items"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if err := checkDiagnosticReport(v.Kind, v.ResultID, v.Items != nil); err != nil {
+		return err
+	}
+	*r = DocumentDiagnosticReport(v.report)
+	if v.Items != nil {
+		r.Items = *v.Items
+	}
+	return nil
+}
+
+// Full returns r as a full report, and whether it is one.
+func (r DocumentDiagnosticReport) Full() (RelatedFullDocumentDiagnosticReport, bool) {
+	if r.Kind != DocumentDiagnosticReportKindFull {
+		return RelatedFullDocumentDiagnosticReport{}, false
+	}
+	return RelatedFullDocumentDiagnosticReport{
+		FullDocumentDiagnosticReport: FullDocumentDiagnosticReport{ResultID: r.ResultID, Items: r.Items},
+		RelatedDocuments:             r.RelatedDocuments,
+	}, true
+}
+
+// Unchanged returns r as an unchanged report, and whether it is one.
+func (r DocumentDiagnosticReport) Unchanged() (RelatedUnchangedDocumentDiagnosticReport, bool) {
+	if r.Kind != DocumentDiagnosticReportKindUnchanged {
+		return RelatedUnchangedDocumentDiagnosticReport{}, false
+	}
+	return RelatedUnchangedDocumentDiagnosticReport{
+		UnchangedDocumentDiagnosticReport: UnchangedDocumentDiagnosticReport{ResultID: r.ResultID},
+		RelatedDocuments:                  r.RelatedDocuments,
+	}, true
+}
+
+// FullDocumentDiagnosticReport is the content of a full diagnostic report.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#fullDocumentDiagnosticReport
+type FullDocumentDiagnosticReport struct {
+	// ResultID identifies the report, if the server sent one.
+	ResultID string
+
+	// Items are the diagnostics of the document.
+	Items []Diagnostic
+}
+
+// UnchangedDocumentDiagnosticReport is the content of a diagnostic report
+// indicating that the previous report is still valid.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#unchangedDocumentDiagnosticReport
+type UnchangedDocumentDiagnosticReport struct {
+	// ResultID identifies the report that is still valid.
+	ResultID string
+}
+
+// RelatedFullDocumentDiagnosticReport is a full diagnostic report with the
+// reports of related documents, as returned by
+// DocumentDiagnosticReport.Full.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#relatedFullDocumentDiagnosticReport
+type RelatedFullDocumentDiagnosticReport struct {
+	FullDocumentDiagnosticReport
+
+	// RelatedDocuments holds the reports of related documents.
+	RelatedDocuments map[DocumentURI]DocumentDiagnosticReport
+}
+
+// RelatedUnchangedDocumentDiagnosticReport is an unchanged diagnostic
+// report with the reports of related documents, as returned by
+// DocumentDiagnosticReport.Unchanged.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#relatedUnchangedDocumentDiagnosticReport
+type RelatedUnchangedDocumentDiagnosticReport struct {
+	UnchangedDocumentDiagnosticReport
+
+	// RelatedDocuments holds the reports of related documents.
+	RelatedDocuments map[DocumentURI]DocumentDiagnosticReport
+}
+
+// DocumentDiagnosticReportPartialResult is a partial result of the
+// textDocument/diagnostic request, adding reports of related documents.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentDiagnosticReportPartialResult
+type DocumentDiagnosticReportPartialResult struct {
+	RelatedDocuments map[DocumentURI]DocumentDiagnosticReport `json:"relatedDocuments"`
+}
+
+// DiagnosticServerCancellationData is the data of the ServerCancelled
+// response error of a diagnostic request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#diagnosticServerCancellationData
+type DiagnosticServerCancellationData struct {
+	// RetriggerRequest asks the client to send the request again.
+	RetriggerRequest bool `json:"retriggerRequest"`
+}
+
+// WorkspaceDiagnosticParams are the parameters of the workspace/diagnostic
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspace_diagnostic
+type WorkspaceDiagnosticParams struct {
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// Identifier is the identifier the server registered the diagnostic
+	// provider with.
+	Identifier string `json:"identifier,omitempty"`
+
+	// PreviousResultIDs are the result ids of the reports the client
+	// currently holds.
+	PreviousResultIDs []PreviousResultID `json:"previousResultIds"`
+}
+
+// PreviousResultID is the result id of a report the client holds for a
+// document.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#previousResultId
+type PreviousResultID struct {
+	URI   DocumentURI `json:"uri"`
+	Value string      `json:"value"`
+}
+
+// WorkspaceDiagnosticReport is the result of the workspace/diagnostic
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspaceDiagnosticReport
+type WorkspaceDiagnosticReport struct {
+	Items []WorkspaceDocumentDiagnosticReport `json:"items"`
+}
+
+// WorkspaceDiagnosticReportPartialResult is a partial result of the
+// workspace/diagnostic request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspaceDiagnosticReportPartialResult
+type WorkspaceDiagnosticReportPartialResult struct {
+	Items []WorkspaceDocumentDiagnosticReport `json:"items"`
+}
+
+// WorkspaceDocumentDiagnosticReport is the report of one document in a
+// workspace diagnostic report, full or unchanged like a
+// DocumentDiagnosticReport.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspaceDocumentDiagnosticReport
+type WorkspaceDocumentDiagnosticReport struct {
+	Kind     DocumentDiagnosticReportKind `json:"kind"`
+	ResultID string                       `json:"resultId,omitempty"`
+	Items    []Diagnostic                 `json:"items,omitempty"`
+
+	// URI is the document the report is for.
+	URI DocumentURI `json:"uri"`
+
+	// Version is the version of the document the report was computed
+	// for, or nil if the document is not open.
+	Version *Integer `json:"version"`
+}
+
+// MarshalJSON implements json.Marshaler. A full report always carries the
+// items member, and an unchanged report never does.
+func (r WorkspaceDocumentDiagnosticReport) MarshalJSON() ([]byte, error) {
+	type report WorkspaceDocumentDiagnosticReport
+	if r.Kind == DocumentDiagnosticReportKindFull {
+		return json.Marshal(struct {
+			report
+			Items []Diagnostic `json:"items"`
+		}{report(r), nonNilDiagnostics(r.Items)})
+	}
+	r.Items = nil
+	return json.Marshal(report(r))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The kind property must name a
+// full report with items or an unchanged report with a result id.
+func (r *WorkspaceDocumentDiagnosticReport) UnmarshalJSON(data []byte) error {
+	type report WorkspaceDocumentDiagnosticReport
+	var v struct {
+		report
+		Items *[]Diagnostic `json:"items"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if err := checkDiagnosticReport(v.Kind, v.ResultID, v.Items != nil); err != nil {
+		return err
+	}
+	*r = WorkspaceDocumentDiagnosticReport(v.report)
+	if v.Items != nil {
+		r.Items = *v.Items
+	}
+	return nil
+}
+
+// checkDiagnosticReport checks the properties of a decoded diagnostic
+// report against its kind.
+func checkDiagnosticReport(kind DocumentDiagnosticReportKind, resultID string, hasItems bool) error {
+	switch kind {
+	case DocumentDiagnosticReportKindFull:
+		if !hasItems {
+			return errors.New("golsptoolkit: full diagnostic report has no items")
+		}
+	case DocumentDiagnosticReportKindUnchanged:
+		if resultID == "" {
+			return errors.New("golsptoolkit: unchanged diagnostic report has no resultId")
+		}
+	default:
+		return fmt.Errorf("golsptoolkit: unknown diagnostic report kind %q", kind)
+	}
+	return nil
+}
+
+// nonNilDiagnostics returns d, or an empty slice if d is nil, so that it is
+// sent as an empty array instead of null.
+func nonNilDiagnostics(d []Diagnostic) []Diagnostic {
+	if d == nil {
+		return []Diagnostic{}
+	}
+	return d
+}
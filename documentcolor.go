@@ -0,0 +1,100 @@
+package golsptoolkit
+
+// Methods of document colors.
+const (
+	MethodTextDocumentDocumentColor     = "textDocument/documentColor"
+	MethodTextDocumentColorPresentation = "textDocument/colorPresentation"
+)
+
+// DocumentColorOptions represents the document color options a server
+// announces in ServerCapabilities.ColorProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentColorOptions
+type DocumentColorOptions struct {
+	WorkDoneProgressOptions
+}
+
+// DocumentColorRegistrationOptions are the options of a dynamic or static
+// registration of document colors.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentColorRegistrationOptions
+type DocumentColorRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	StaticRegistrationOptions
+	DocumentColorOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (DocumentColorRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentDocumentColor
+}
+
+// DocumentColorParams are the parameters of the textDocument/documentColor
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentColorParams
+type DocumentColorParams struct {
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// TextDocument is the document to find colors in.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+// Color represents a color in RGBA space. Each component is in the range
+// [0, 1].
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#color
+type Color struct {
+	Red   Decimal `json:"red"`
+	Green Decimal `json:"green"`
+	Blue  Decimal `json:"blue"`
+	Alpha Decimal `json:"alpha"`
+}
+
+// ColorInformation represents a color found in a document.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#colorInformation
+type ColorInformation struct {
+	// Range is the range of the color in the document.
+	Range Range `json:"range"`
+
+	// Color is the value of the color.
+	Color Color `json:"color"`
+}
+
+// ColorPresentationParams are the parameters of the
+// textDocument/colorPresentation request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#colorPresentationParams
+type ColorPresentationParams struct {
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// TextDocument is the document.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+
+	// Color is the color to present.
+	Color Color `json:"color"`
+
+	// Range is the range where the color would be inserted.
+	Range Range `json:"range"`
+}
+
+// ColorPresentation represents a way to write a color in a document, such
+// as "#ff0000" or "rgb(255, 0, 0)".
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#colorPresentation
+type ColorPresentation struct {
+	// Label is shown in the color picker header and, by default, is the
+	// text inserted when the presentation is picked.
+	Label string `json:"label"`
+
+	// TextEdit is applied when the presentation is picked, instead of
+	// inserting Label.
+	TextEdit *TextEdit `json:"textEdit,omitempty"`
+
+	// AdditionalTextEdits are applied along with the main edit. They must
+	// not overlap it or each other.
+	AdditionalTextEdits []TextEdit `json:"additionalTextEdits,omitempty"`
+}
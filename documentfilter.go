@@ -0,0 +1,78 @@
+package golsptoolkit
+
+import (
+	"net/url"
+	"strings"
+)
+
+// DocumentFilter denotes a document by properties such as its language,
+// the scheme of its URI, or a glob pattern applied to its path. At least
+// one property must be set.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentFilter
+type DocumentFilter struct {
+	// Language is a language identifier, such as "go".
+	Language string `json:"language,omitempty"`
+
+	// Scheme is a URI scheme, such as "file" or "untitled".
+	Scheme string `json:"scheme,omitempty"`
+
+	// Pattern is a glob pattern, such as "*.{ts,js}", matched against the
+	// path of the document URI. A pattern without a slash is matched
+	// against the last element of the path.
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// Match reports whether f matches the document at uri with the language
+// identifier languageID. Every property set must match; a filter with no
+// property set matches nothing.
+func (f DocumentFilter) Match(uri DocumentURI, languageID string) bool {
+	if f.Language == "" && f.Scheme == "" && f.Pattern == "" {
+		return false
+	}
+	if f.Language != "" && f.Language != languageID {
+		return false
+	}
+	scheme, path, ok := splitURI(uri)
+	if !ok || (f.Scheme != "" && f.Scheme != scheme) {
+		return false
+	}
+	if f.Pattern == "" {
+		return true
+	}
+	if !strings.Contains(f.Pattern, "/") {
+		path = path[strings.LastIndexByte(path, '/')+1:]
+	}
+	return globMatch(f.Pattern, path, false)
+}
+
+// DocumentSelector is a combination of document filters. A document is
+// selected if any filter matches it. Servers can use Match to route
+// documents to the features they registered with a selector.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentSelector
+type DocumentSelector []DocumentFilter
+
+// Match reports whether any filter of s matches the document at uri with
+// the language identifier languageID.
+func (s DocumentSelector) Match(uri DocumentURI, languageID string) bool {
+	for _, f := range s {
+		if f.Match(uri, languageID) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitURI returns the scheme and the path of uri. The path of an opaque
+// URI, such as "untitled:Untitled-1", is its opaque part.
+func splitURI(uri string) (scheme, path string, ok bool) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", "", false
+	}
+	if u.Path == "" {
+		return u.Scheme, u.Opaque, true
+	}
+	return u.Scheme, u.Path, true
+}
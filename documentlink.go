@@ -0,0 +1,67 @@
+package golsptoolkit
+
+// Methods of document links.
+const (
+	MethodTextDocumentDocumentLink = "textDocument/documentLink"
+	MethodDocumentLinkResolve      = "documentLink/resolve"
+)
+
+// DocumentLinkOptions represents the document link options a server
+// announces in ServerCapabilities.DocumentLinkProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentLinkOptions
+type DocumentLinkOptions struct {
+	WorkDoneProgressOptions
+
+	// ResolveProvider reports whether the server computes the targets of
+	// document links with documentLink/resolve.
+	ResolveProvider bool `json:"resolveProvider,omitempty"`
+}
+
+// DocumentLinkRegistrationOptions are the options of a dynamic registration of
+// document links.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentLinkRegistrationOptions
+type DocumentLinkRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	DocumentLinkOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (DocumentLinkRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentDocumentLink
+}
+
+// DocumentLinkParams are the parameters of the textDocument/documentLink
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentLinkParams
+type DocumentLinkParams struct {
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// TextDocument is the document to compute links for.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+// DocumentLink represents a range in a text document that links to an
+// internal or external resource, such as another text document or a web
+// site. A link without a target is unresolved; its target is computed by
+// documentLink/resolve.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentLink
+type DocumentLink struct {
+	// Range is the range the link applies to.
+	Range Range `json:"range"`
+
+	// Target is the URI the link points to.
+	Target URI `json:"target,omitempty"`
+
+	// Tooltip is shown when hovering over the link. It needs the client's
+	// tooltipSupport capability.
+	Tooltip string `json:"tooltip,omitempty"`
+
+	// Data is preserved by the client between a documentLink and a
+	// documentLink/resolve request.
+	Data LSPAny `json:"data,omitempty"`
+}
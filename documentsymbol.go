@@ -0,0 +1,290 @@
+package golsptoolkit
+
+import "encoding/json"
+
+// Methods of document symbols.
+const (
+	MethodTextDocumentDocumentSymbol = "textDocument/documentSymbol"
+)
+
+// DocumentSymbolOptions represents the document symbol options a server
+// announces in ServerCapabilities.DocumentSymbolProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentSymbolOptions
+type DocumentSymbolOptions struct {
+	WorkDoneProgressOptions
+
+	// Label is a human-readable string shown when multiple outlines are
+	// shown for the same document.
+	Label string `json:"label,omitempty"`
+}
+
+// DocumentSymbolRegistrationOptions are the options of a dynamic registration
+// of document symbol.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentSymbolRegistrationOptions
+type DocumentSymbolRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	DocumentSymbolOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (DocumentSymbolRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentDocumentSymbol
+}
+
+// SymbolKind represents the kind of a symbol.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#symbolKind
+type SymbolKind UInteger
+
+const (
+	SymbolKindFile          SymbolKind = 1
+	SymbolKindModule        SymbolKind = 2
+	SymbolKindNamespace     SymbolKind = 3
+	SymbolKindPackage       SymbolKind = 4
+	SymbolKindClass         SymbolKind = 5
+	SymbolKindMethod        SymbolKind = 6
+	SymbolKindProperty      SymbolKind = 7
+	SymbolKindField         SymbolKind = 8
+	SymbolKindConstructor   SymbolKind = 9
+	SymbolKindEnum          SymbolKind = 10
+	SymbolKindInterface     SymbolKind = 11
+	SymbolKindFunction      SymbolKind = 12
+	SymbolKindVariable      SymbolKind = 13
+	SymbolKindConstant      SymbolKind = 14
+	SymbolKindString        SymbolKind = 15
+	SymbolKindNumber        SymbolKind = 16
+	SymbolKindBoolean       SymbolKind = 17
+	SymbolKindArray         SymbolKind = 18
+	SymbolKindObject        SymbolKind = 19
+	SymbolKindKey           SymbolKind = 20
+	SymbolKindNull          SymbolKind = 21
+	SymbolKindEnumMember    SymbolKind = 22
+	SymbolKindStruct        SymbolKind = 23
+	SymbolKindEvent         SymbolKind = 24
+	SymbolKindOperator      SymbolKind = 25
+	SymbolKindTypeParameter SymbolKind = 26
+)
+
+var symbolKindNames = enumNames{"SymbolKind", []string{
+	SymbolKindFile:          "File",
+	SymbolKindModule:        "Module",
+	SymbolKindNamespace:     "Namespace",
+	SymbolKindPackage:       "Package",
+	SymbolKindClass:         "Class",
+	SymbolKindMethod:        "Method",
+	SymbolKindProperty:      "Property",
+	SymbolKindField:         "Field",
+	SymbolKindConstructor:   "Constructor",
+	SymbolKindEnum:          "Enum",
+	SymbolKindInterface:     "Interface",
+	SymbolKindFunction:      "Function",
+	SymbolKindVariable:      "Variable",
+	SymbolKindConstant:      "Constant",
+	SymbolKindString:        "String",
+	SymbolKindNumber:        "Number",
+	SymbolKindBoolean:       "Boolean",
+	SymbolKindArray:         "Array",
+	SymbolKindObject:        "Object",
+	SymbolKindKey:           "Key",
+	SymbolKindNull:          "Null",
+	SymbolKindEnumMember:    "EnumMember",
+	SymbolKindStruct:        "Struct",
+	SymbolKindEvent:         "Event",
+	SymbolKindOperator:      "Operator",
+	SymbolKindTypeParameter: "TypeParameter",
+}}
+
+// String returns the name of k, such as "File".
+func (k SymbolKind) String() string {
+	return symbolKindNames.name(int64(k))
+}
+
+// IsValid reports whether k is one of the defined symbol kinds.
+func (k SymbolKind) IsValid() bool {
+	return symbolKindNames.valid(int64(k))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Undefined values are rejected.
+func (k *SymbolKind) UnmarshalJSON(data []byte) error {
+	return decodeEnum(data, k, symbolKindNames)
+}
+
+// SymbolTag represents an extra annotation that tweaks the rendering of a
+// symbol.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#symbolTag
+type SymbolTag UInteger
+
+const (
+	// SymbolTagDeprecated renders the symbol as obsolete, usually with a
+	// strike-out.
+	SymbolTagDeprecated SymbolTag = 1
+)
+
+var symbolTagNames = enumNames{"SymbolTag", []string{
+	SymbolTagDeprecated: "Deprecated",
+}}
+
+// String returns the name of t, such as "Deprecated".
+func (t SymbolTag) String() string {
+	return symbolTagNames.name(int64(t))
+}
+
+// IsValid reports whether t is one of the defined symbol tags.
+func (t SymbolTag) IsValid() bool {
+	return symbolTagNames.valid(int64(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Undefined values are rejected.
+func (t *SymbolTag) UnmarshalJSON(data []byte) error {
+	return decodeEnum(data, t, symbolTagNames)
+}
+
+// DocumentSymbolParams are the parameters of the textDocument/documentSymbol
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentSymbolParams
+type DocumentSymbolParams struct {
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// TextDocument is the document to list the symbols of.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+// DocumentSymbol represents a programming construct like a variable, class
+// or interface in a document. Document symbols are hierarchical.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentSymbol
+type DocumentSymbol struct {
+	// Name is the name of the symbol. It must not be empty.
+	Name string `json:"name"`
+
+	// Detail is more detail for the symbol, such as a function signature.
+	Detail string `json:"detail,omitempty"`
+
+	// Kind is the kind of the symbol.
+	Kind SymbolKind `json:"kind"`
+
+	// Tags tweak the rendering of the symbol.
+	Tags []SymbolTag `json:"tags,omitempty"`
+
+	// Deprecated reports whether the symbol is deprecated.
+	//
+	// Deprecated: Use Tags instead.
+	Deprecated bool `json:"deprecated,omitempty"`
+
+	// Range encloses the symbol, including leading and trailing comments,
+	// and is used to find the symbol under the cursor.
+	Range Range `json:"range"`
+
+	// SelectionRange is selected and revealed when the symbol is picked,
+	// such as the name of a function. It must be contained in Range.
+	SelectionRange Range `json:"selectionRange"`
+
+	// Children are the symbols contained in the symbol, such as the fields
+	// of a struct.
+	Children []DocumentSymbol `json:"children,omitempty"`
+}
+
+// SymbolInformation represents information about a programming construct
+// like a variable, class or interface. Unlike DocumentSymbol it is flat.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#symbolInformation
+type SymbolInformation struct {
+	// Name is the name of the symbol.
+	Name string `json:"name"`
+
+	// Kind is the kind of the symbol.
+	Kind SymbolKind `json:"kind"`
+
+	// Tags tweak the rendering of the symbol.
+	Tags []SymbolTag `json:"tags,omitempty"`
+
+	// Deprecated reports whether the symbol is deprecated.
+	//
+	// Deprecated: Use Tags instead.
+	Deprecated bool `json:"deprecated,omitempty"`
+
+	// Location is the location of the symbol. Its range should enclose the
+	// symbol, as DocumentSymbol.Range does.
+	Location Location `json:"location"`
+
+	// ContainerName is the name of the symbol containing the symbol, used
+	// as a qualifier in the user interface.
+	ContainerName string `json:"containerName,omitempty"`
+}
+
+// DocumentSymbolResult is the result of the textDocument/documentSymbol
+// request: either hierarchical DocumentSymbols, or flat SymbolInformation
+// for clients without hierarchicalDocumentSymbolSupport.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_documentSymbol
+type DocumentSymbolResult struct {
+	// Symbols holds the result if Information is nil.
+	Symbols []DocumentSymbol
+
+	// Information holds the result if it is flat.
+	Information []SymbolInformation
+}
+
+// NewDocumentSymbolResult returns symbols, the symbols of the document at
+// uri, in the shape permitted by caps: as is if the client announced
+// hierarchicalDocumentSymbolSupport, and otherwise flattened into
+// SymbolInformation in pre-order, with each symbol's ContainerName set to
+// the name of its parent. caps may be nil.
+func NewDocumentSymbolResult(uri DocumentURI, symbols []DocumentSymbol, caps *ClientCapabilities) DocumentSymbolResult {
+	if caps != nil && caps.TextDocument != nil && caps.TextDocument.DocumentSymbol != nil && caps.TextDocument.DocumentSymbol.HierarchicalDocumentSymbolSupport {
+		return DocumentSymbolResult{Symbols: symbols}
+	}
+	info := []SymbolInformation{}
+	var flatten func(symbols []DocumentSymbol, container string)
+	flatten = func(symbols []DocumentSymbol, container string) {
+		for _, s := range symbols {
+			info = append(info, SymbolInformation{
+				Name:          s.Name,
+				Kind:          s.Kind,
+				Tags:          s.Tags,
+				Deprecated:    s.Deprecated,
+				Location:      Location{URI: uri, Range: s.Range},
+				ContainerName: container,
+			})
+			flatten(s.Children, s.Name)
+		}
+	}
+	flatten(symbols, "")
+	return DocumentSymbolResult{Information: info}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r DocumentSymbolResult) MarshalJSON() ([]byte, error) {
+	if r.Information != nil {
+		return json.Marshal(r.Information)
+	}
+	if r.Symbols == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(r.Symbols)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts an array of
+// DocumentSymbol or an array of SymbolInformation, told apart by the
+// location property.
+func (r *DocumentSymbolResult) UnmarshalJSON(data []byte) error {
+	*r = DocumentSymbolResult{}
+	var elems []json.RawMessage
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return err
+	}
+	if len(elems) > 0 {
+		var probe struct {
+			Location *json.RawMessage `json:"location"`
+		}
+		if err := json.Unmarshal(elems[0], &probe); err == nil && probe.Location != nil {
+			return json.Unmarshal(data, &r.Information)
+		}
+	}
+	return json.Unmarshal(data, &r.Symbols)
+}
@@ -0,0 +1,338 @@
+package golsptoolkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Methods of text document synchronization.
+const (
+	MethodTextDocumentDidOpen           = "textDocument/didOpen"
+	MethodTextDocumentDidChange         = "textDocument/didChange"
+	MethodTextDocumentWillSave          = "textDocument/willSave"
+	MethodTextDocumentWillSaveWaitUntil = "textDocument/willSaveWaitUntil"
+	MethodTextDocumentDidSave           = "textDocument/didSave"
+	MethodTextDocumentDidClose          = "textDocument/didClose"
+)
+
+// TextDocumentSyncKind represents how the client sends document changes to
+// the server.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocumentSyncKind
+type TextDocumentSyncKind UInteger
+
+const (
+	// TextDocumentSyncKindNone means documents are not synced.
+	TextDocumentSyncKindNone TextDocumentSyncKind = 0
+
+	// TextDocumentSyncKindFull means the client sends the full content of
+	// the document on every change.
+	TextDocumentSyncKindFull TextDocumentSyncKind = 1
+
+	// TextDocumentSyncKindIncremental means the client sends incremental
+	// changes, after sending the full content on open.
+	TextDocumentSyncKindIncremental TextDocumentSyncKind = 2
+)
+
+var textDocumentSyncKindNames = enumNames{"TextDocumentSyncKind", []string{
+	TextDocumentSyncKindNone:        "None",
+	TextDocumentSyncKindFull:        "Full",
+	TextDocumentSyncKindIncremental: "Incremental",
+}}
+
+// String returns the name of k, such as "None".
+func (k TextDocumentSyncKind) String() string {
+	return textDocumentSyncKindNames.name(int64(k))
+}
+
+// IsValid reports whether k is one of the defined sync kinds.
+func (k TextDocumentSyncKind) IsValid() bool {
+	return textDocumentSyncKindNames.valid(int64(k))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Undefined values are rejected.
+func (k *TextDocumentSyncKind) UnmarshalJSON(data []byte) error {
+	return decodeEnum(data, k, textDocumentSyncKindNames)
+}
+
+// TextDocumentSyncOptions represents the document sync options a server
+// announces in ServerCapabilities.TextDocumentSync.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_synchronization
+type TextDocumentSyncOptions struct {
+	// OpenClose reports whether didOpen and didClose notifications are
+	// sent to the server.
+	OpenClose bool `json:"openClose,omitempty"`
+
+	// Change is how didChange notifications are sent to the server. If
+	// omitted, it is TextDocumentSyncKindNone.
+	Change TextDocumentSyncKind `json:"change,omitempty"`
+
+	// WillSave reports whether willSave notifications are sent to the
+	// server.
+	WillSave bool `json:"willSave,omitempty"`
+
+	// WillSaveWaitUntil reports whether willSaveWaitUntil requests are
+	// sent to the server.
+	WillSaveWaitUntil bool `json:"willSaveWaitUntil,omitempty"`
+
+	// Save is true, or the save options, if didSave notifications are sent
+	// to the server.
+	Save Or2[bool, SaveOptions] `json:"save,omitzero"`
+}
+
+// SaveOptions are the options of didSave notifications.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#saveOptions
+type SaveOptions struct {
+	// IncludeText reports whether the client includes the content of the
+	// document when it is saved.
+	IncludeText bool `json:"includeText,omitempty"`
+}
+
+// TextDocumentChangeRegistrationOptions are the options of a dynamic
+// registration of textDocument/didChange notifications.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocumentChangeRegistrationOptions
+type TextDocumentChangeRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+
+	// SyncKind is how documents are synced to the server.
+	SyncKind TextDocumentSyncKind `json:"syncKind"`
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (TextDocumentChangeRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentDidChange
+}
+
+// DidOpenTextDocumentParams are the parameters of the textDocument/didOpen
+// notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_didOpen
+type DidOpenTextDocumentParams struct {
+	// TextDocument is the document that was opened.
+	TextDocument TextDocumentItem `json:"textDocument"`
+}
+
+// DidChangeTextDocumentParams are the parameters of the
+// textDocument/didChange notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_didChange
+type DidChangeTextDocumentParams struct {
+	// TextDocument is the document that changed, with its version after
+	// all content changes are applied.
+	TextDocument VersionedTextDocumentIdentifier `json:"textDocument"`
+
+	// ContentChanges are the changes, to be applied in order: each change
+	// describes the document as left by the previous one.
+	ContentChanges []TextDocumentContentChangeEvent `json:"contentChanges"`
+}
+
+// TextDocumentContentChangeEvent represents a change to a text document:
+// either a replacement of Range by Text, or, if Range is nil, the full new
+// content of the document.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocumentContentChangeEvent
+type TextDocumentContentChangeEvent struct {
+	// Range is the range of the document that changed, or nil if Text is
+	// the full content.
+	Range *Range `json:"range,omitempty"`
+
+	// RangeLength is the length of the replaced range.
+	//
+	// Deprecated: Use Range instead.
+	RangeLength *UInteger `json:"rangeLength,omitempty"`
+
+	// Text is the new text of the range, or of the whole document.
+	Text string `json:"text"`
+}
+
+// IsFull reports whether e replaces the full content of the document.
+func (e TextDocumentContentChangeEvent) IsFull() bool {
+	return e.Range == nil
+}
+
+// NewFullContentChange returns a change replacing the full content of the
+// document with text.
+func NewFullContentChange(text string) TextDocumentContentChangeEvent {
+	return TextDocumentContentChangeEvent{Text: text}
+}
+
+// NewIncrementalContentChange returns a change replacing rng with text.
+func NewIncrementalContentChange(rng Range, text string) TextDocumentContentChangeEvent {
+	return TextDocumentContentChangeEvent{Range: &rng, Text: text}
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A change without a range,
+// or with a null one, replaces the full content; the text property is
+// required in both cases.
+func (e *TextDocumentContentChangeEvent) UnmarshalJSON(data []byte) error {
+	type event TextDocumentContentChangeEvent
+	var v struct {
+		event
+		Text *string `json:"text"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Text == nil {
+		return errors.New("golsptoolkit: content change event has no text")
+	}
+	if v.Range == nil && v.RangeLength != nil {
+		return errors.New("golsptoolkit: content change event has a rangeLength but no range")
+	}
+	*e = TextDocumentContentChangeEvent(v.event)
+	e.Text = *v.Text
+	return nil
+}
+
+// ApplyContentChanges applies changes, in order, to text and returns the
+// result. The character offsets of the ranges are counted in encoding, the
+// position encoding of the session.
+func ApplyContentChanges(text string, changes []TextDocumentContentChangeEvent, encoding PositionEncodingKind) string {
+	for _, c := range changes {
+		if c.IsFull() {
+			text = c.Text
+			continue
+		}
+		start := PositionToOffset(text, c.Range.Start, encoding)
+		end := max(start, PositionToOffset(text, c.Range.End, encoding))
+		text = text[:start] + c.Text + text[end:]
+	}
+	return text
+}
+
+// TextDocumentSaveReason represents why a text document is saved.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocumentSaveReason
+type TextDocumentSaveReason UInteger
+
+const (
+	// TextDocumentSaveReasonManual means the save was triggered
+	// explicitly, for example by the user pressing save or by an API call.
+	TextDocumentSaveReasonManual TextDocumentSaveReason = 1
+
+	// TextDocumentSaveReasonAfterDelay means the save was triggered
+	// automatically after a delay.
+	TextDocumentSaveReasonAfterDelay TextDocumentSaveReason = 2
+
+	// TextDocumentSaveReasonFocusOut means the save was triggered when the
+	// editor lost focus.
+	TextDocumentSaveReasonFocusOut TextDocumentSaveReason = 3
+)
+
+var textDocumentSaveReasonNames = enumNames{"TextDocumentSaveReason", []string{
+	TextDocumentSaveReasonManual:     "Manual",
+	TextDocumentSaveReasonAfterDelay: "AfterDelay",
+	TextDocumentSaveReasonFocusOut:   "FocusOut",
+}}
+
+// String returns the name of r, such as "Manual".
+func (r TextDocumentSaveReason) String() string {
+	return textDocumentSaveReasonNames.name(int64(r))
+}
+
+// IsValid reports whether r is one of the defined save reasons.
+func (r TextDocumentSaveReason) IsValid() bool {
+	return textDocumentSaveReasonNames.valid(int64(r))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Undefined values are rejected.
+func (r *TextDocumentSaveReason) UnmarshalJSON(data []byte) error {
+	return decodeEnum(data, r, textDocumentSaveReasonNames)
+}
+
+// WillSaveTextDocumentParams are the parameters of the
+// textDocument/willSave notification and the
+// textDocument/willSaveWaitUntil request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#willSaveTextDocumentParams
+type WillSaveTextDocumentParams struct {
+	// TextDocument is the document that will be saved.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+
+	// Reason is why the document is saved.
+	Reason TextDocumentSaveReason `json:"reason"`
+}
+
+// HandleWillSaveWaitUntil registers fn on mux as the handler for
+// textDocument/willSaveWaitUntil requests. The client holds the save until
+// it receives the edits, so if timeout is positive and fn has not returned
+// by then, the request is answered with no edits and the context passed to
+// fn is canceled; the late result of fn is discarded.
+func HandleWillSaveWaitUntil(mux *Mux, timeout time.Duration, fn func(ctx context.Context, params WillSaveTextDocumentParams) ([]TextEdit, error)) {
+	mux.RegisterFunc(MethodTextDocumentWillSaveWaitUntil, func(ctx context.Context, reply Replier, req *Request) {
+		params, err := decodeParams[WillSaveTextDocumentParams](req.Params)
+		if err != nil {
+			reply(ctx, nil, err)
+			return
+		}
+		if timeout <= 0 {
+			edits, err := fn(ctx, params)
+			reply(ctx, edits, err)
+			return
+		}
+
+		fnCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		type result struct {
+			edits []TextEdit
+			err   error
+		}
+		done := make(chan result, 1)
+		go func() {
+			edits, err := fn(fnCtx, params)
+			done <- result{edits, err}
+		}()
+
+		select {
+		case r := <-done:
+			reply(ctx, r.edits, r.err)
+		case <-fnCtx.Done():
+			if err := ctx.Err(); err != nil {
+				reply(ctx, nil, err)
+				return
+			}
+			reply(ctx, []TextEdit{}, nil)
+		}
+	})
+}
+
+// TextDocumentSaveRegistrationOptions are the options of a dynamic
+// registration of textDocument/didSave notifications.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocumentSaveRegistrationOptions
+type TextDocumentSaveRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	SaveOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (TextDocumentSaveRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentDidSave
+}
+
+// DidSaveTextDocumentParams are the parameters of the textDocument/didSave
+// notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_didSave
+type DidSaveTextDocumentParams struct {
+	// TextDocument is the document that was saved.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+
+	// Text is the content when saved. It is only set if the server asked
+	// for it with includeText.
+	Text *string `json:"text,omitempty"`
+}
+
+// DidCloseTextDocumentParams are the parameters of the
+// textDocument/didClose notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_didClose
+type DidCloseTextDocumentParams struct {
+	// TextDocument is the document that was closed.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
@@ -0,0 +1,60 @@
+package golsptoolkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// enumNames describes an enumeration of the protocol: the name of its type
+// and the names of its values, indexed by value. Values without a name are
+// not defined.
+type enumNames struct {
+	typ   string
+	names []string
+}
+
+// name returns the name of v, or the type and number of v if it is not
+// defined.
+func (e enumNames) name(v int64) string {
+	if e.valid(v) {
+		return e.names[v]
+	}
+	return e.typ + "(" + strconv.FormatInt(v, 10) + ")"
+}
+
+// valid reports whether v is defined.
+func (e enumNames) valid(v int64) bool {
+	return v >= 0 && v < int64(len(e.names)) && e.names[v] != ""
+}
+
+// decodeEnum decodes an enumeration value into v, rejecting values that are
+// not defined. Decoding request parameters, the error is reported to the
+// peer as InvalidParams.
+func decodeEnum[T ~int32 | ~uint32](data []byte, v *T, e enumNames) error {
+	var n int64
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("golsptoolkit: invalid %s %s", e.typ, data)
+	}
+	if !e.valid(n) {
+		return fmt.Errorf("golsptoolkit: invalid %s %d", e.typ, n)
+	}
+	*v = T(n)
+	return nil
+}
+
+// knownEnumValues returns the defined values of values, the valueSet of a
+// client capability, dropping the others: clients may list values added by
+// later versions of the protocol.
+func knownEnumValues[T ~int32 | ~uint32](values []int64, e enumNames) []T {
+	if values == nil {
+		return nil
+	}
+	known := make([]T, 0, len(values))
+	for _, v := range values {
+		if e.valid(v) {
+			known = append(known, T(v))
+		}
+	}
+	return known
+}
@@ -0,0 +1,78 @@
+package golsptoolkit
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// NewError returns a *ResponseError with the given code and message, for
+// handlers to return when a request fails:
+//
+//	return nil, golsptoolkit.NewError(golsptoolkit.InvalidParams, "unknown document")
+func NewError(code Integer, message string) *ResponseError {
+	return &ResponseError{Code: code, Message: message}
+}
+
+// Errorf is like NewError but formats the message according to a format
+// specifier.
+func Errorf(code Integer, format string, args ...any) *ResponseError {
+	return NewError(code, fmt.Sprintf(format, args...))
+}
+
+// WrapError returns a *ResponseError with the given code carrying err's
+// message. The result unwraps to err, so errors.Is and errors.As see through
+// it on the local side; only the code and message reach the peer. A nil err
+// yields nil.
+func WrapError(code Integer, err error) *ResponseError {
+	if err == nil {
+		return nil
+	}
+	return &ResponseError{Code: code, Message: err.Error(), cause: err}
+}
+
+// Unwrap returns the error passed to WrapError, if any.
+func (e *ResponseError) Unwrap() error {
+	return e.cause
+}
+
+// Is reports whether target is a *ResponseError with the same code, so that
+// errors can be classified without comparing messages:
+//
+//	if errors.Is(err, golsptoolkit.NewError(golsptoolkit.RequestCancelled, "")) {
+func (e *ResponseError) Is(target error) bool {
+	t, ok := target.(*ResponseError)
+	return ok && t != nil && e.Code == t.Code
+}
+
+// WithData returns a copy of e carrying data as its Data member, for sending
+// machine-readable failure details along with the error:
+//
+//	return nil, golsptoolkit.NewError(golsptoolkit.RequestFailed, "not applicable").WithData(reason)
+func (e *ResponseError) WithData(data any) *ResponseError {
+	c := *e
+	c.Data = data
+	return &c
+}
+
+// ErrData decodes the Data member of err into a T. It reports false if err
+// is nil, carries no data, or the data does not decode as a T.
+func ErrData[T any](err *ResponseError) (T, bool) {
+	var v T
+	if err == nil || err.Data == nil {
+		return v, false
+	}
+	if d, ok := err.Data.(T); ok {
+		return d, true
+	}
+	raw, ok := err.Data.(json.RawMessage)
+	if !ok {
+		var merr error
+		if raw, merr = json.Marshal(err.Data); merr != nil {
+			return v, false
+		}
+	}
+	if json.Unmarshal(raw, &v) != nil {
+		return v, false
+	}
+	return v, true
+}
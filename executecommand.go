@@ -0,0 +1,56 @@
+package golsptoolkit
+
+import "encoding/json"
+
+// Methods of command execution.
+const (
+	MethodWorkspaceExecuteCommand = "workspace/executeCommand"
+)
+
+// ExecuteCommandOptions represents the command options a server announces
+// in ServerCapabilities.ExecuteCommandProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#executeCommandOptions
+type ExecuteCommandOptions struct {
+	WorkDoneProgressOptions
+
+	// Commands lists the commands the server executes.
+	Commands []string `json:"commands"`
+}
+
+// MarshalJSON implements json.Marshaler. Nil Commands are sent as an empty
+// array, since the property is required.
+func (o ExecuteCommandOptions) MarshalJSON() ([]byte, error) {
+	type options ExecuteCommandOptions
+	if o.Commands == nil {
+		o.Commands = []string{}
+	}
+	return json.Marshal(options(o))
+}
+
+// ExecuteCommandRegistrationOptions are the options of a dynamic
+// registration of command execution.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#executeCommandRegistrationOptions
+type ExecuteCommandRegistrationOptions struct {
+	ExecuteCommandOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (ExecuteCommandRegistrationOptions) RegistrationMethod() string {
+	return MethodWorkspaceExecuteCommand
+}
+
+// ExecuteCommandParams are the parameters of the workspace/executeCommand
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#executeCommandParams
+type ExecuteCommandParams struct {
+	WorkDoneProgressParams
+
+	// Command is the identifier of the command to execute.
+	Command string `json:"command"`
+
+	// Arguments are the arguments the command is invoked with.
+	Arguments []LSPAny `json:"arguments,omitempty"`
+}
@@ -0,0 +1,162 @@
+package golsptoolkit
+
+// Methods of file operations.
+const (
+	MethodWorkspaceWillCreateFiles = "workspace/willCreateFiles"
+	MethodWorkspaceDidCreateFiles  = "workspace/didCreateFiles"
+	MethodWorkspaceWillRenameFiles = "workspace/willRenameFiles"
+	MethodWorkspaceDidRenameFiles  = "workspace/didRenameFiles"
+	MethodWorkspaceWillDeleteFiles = "workspace/willDeleteFiles"
+	MethodWorkspaceDidDeleteFiles  = "workspace/didDeleteFiles"
+)
+
+// FileOperationsServerCapabilities lists the file operation requests and
+// notifications a server is interested in, in
+// ServerCapabilities.Workspace.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#serverCapabilities
+type FileOperationsServerCapabilities struct {
+	DidCreate  *FileOperationRegistrationOptions `json:"didCreate,omitempty"`
+	WillCreate *FileOperationRegistrationOptions `json:"willCreate,omitempty"`
+	DidRename  *FileOperationRegistrationOptions `json:"didRename,omitempty"`
+	WillRename *FileOperationRegistrationOptions `json:"willRename,omitempty"`
+	DidDelete  *FileOperationRegistrationOptions `json:"didDelete,omitempty"`
+	WillDelete *FileOperationRegistrationOptions `json:"willDelete,omitempty"`
+}
+
+// FileOperationRegistrationOptions are the options of a registration of
+// file operation requests or notifications.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#fileOperationRegistrationOptions
+type FileOperationRegistrationOptions struct {
+	// Filters select the files the server is interested in.
+	Filters []FileOperationFilter `json:"filters"`
+}
+
+// Matches reports whether any of the filters matches the file or, if isDir
+// is true, folder at uri.
+func (o FileOperationRegistrationOptions) Matches(uri URI, isDir bool) bool {
+	for _, f := range o.Filters {
+		if f.Matches(uri, isDir) {
+			return true
+		}
+	}
+	return false
+}
+
+// FileOperationFilter selects files by URI scheme and pattern.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#fileOperationFilter
+type FileOperationFilter struct {
+	// Scheme is the URI scheme, such as "file". If empty, any scheme is
+	// matched.
+	Scheme string `json:"scheme,omitempty"`
+
+	// Pattern is the pattern the path of the URI must match.
+	Pattern FileOperationPattern `json:"pattern"`
+}
+
+// Matches reports whether f matches the file or, if isDir is true, folder
+// at uri.
+func (f FileOperationFilter) Matches(uri URI, isDir bool) bool {
+	scheme, path, ok := splitURI(uri)
+	if !ok || (f.Scheme != "" && f.Scheme != scheme) {
+		return false
+	}
+	switch f.Pattern.Matches {
+	case FileOperationPatternKindFile:
+		if isDir {
+			return false
+		}
+	case FileOperationPatternKindFolder:
+		if !isDir {
+			return false
+		}
+	}
+	ignoreCase := f.Pattern.Options != nil && f.Pattern.Options.IgnoreCase
+	return globMatch(f.Pattern.Glob, path, ignoreCase)
+}
+
+// FileOperationPattern is a pattern matched against the paths of files and
+// folders.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#fileOperationPattern
+type FileOperationPattern struct {
+	// Glob is the glob pattern, such as "**/*.go".
+	Glob string `json:"glob"`
+
+	// Matches restricts the pattern to files or folders. If empty, both
+	// are matched.
+	Matches FileOperationPatternKind `json:"matches,omitempty"`
+
+	// Options are additional matching options.
+	Options *FileOperationPatternOptions `json:"options,omitempty"`
+}
+
+// FileOperationPatternKind represents whether a pattern matches files or
+// folders.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#fileOperationPatternKind
+type FileOperationPatternKind = string
+
+const (
+	FileOperationPatternKindFile   FileOperationPatternKind = "file"
+	FileOperationPatternKindFolder FileOperationPatternKind = "folder"
+)
+
+// FileOperationPatternOptions are the matching options of a
+// FileOperationPattern.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#fileOperationPatternOptions
+type FileOperationPatternOptions struct {
+	// IgnoreCase matches the pattern case-insensitively.
+	IgnoreCase bool `json:"ignoreCase,omitempty"`
+}
+
+// CreateFilesParams are the parameters of the workspace/willCreateFiles
+// request and the workspace/didCreateFiles notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#createFilesParams
+type CreateFilesParams struct {
+	Files []FileCreate `json:"files"`
+}
+
+// FileCreate represents a file that is created.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#fileCreate
+type FileCreate struct {
+	URI string `json:"uri"`
+}
+
+// RenameFilesParams are the parameters of the workspace/willRenameFiles
+// request and the workspace/didRenameFiles notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#renameFilesParams
+type RenameFilesParams struct {
+	// Files are the files and folders renamed. Renaming a folder renames
+	// its content, which is not listed separately.
+	Files []FileRename `json:"files"`
+}
+
+// FileRename represents a file that is renamed.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#fileRename
+type FileRename struct {
+	OldURI string `json:"oldUri"`
+	NewURI string `json:"newUri"`
+}
+
+// DeleteFilesParams are the parameters of the workspace/willDeleteFiles
+// request and the workspace/didDeleteFiles notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#deleteFilesParams
+type DeleteFilesParams struct {
+	Files []FileDelete `json:"files"`
+}
+
+// FileDelete represents a file that is deleted.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#fileDelete
+type FileDelete struct {
+	URI string `json:"uri"`
+}
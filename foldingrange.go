@@ -0,0 +1,111 @@
+package golsptoolkit
+
+// Methods of folding ranges.
+const (
+	MethodTextDocumentFoldingRange = "textDocument/foldingRange"
+)
+
+// FoldingRangeOptions represents the folding range options a server announces
+// in ServerCapabilities.FoldingRangeProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#foldingRangeOptions
+type FoldingRangeOptions struct {
+	WorkDoneProgressOptions
+}
+
+// FoldingRangeRegistrationOptions are the options of a dynamic or static
+// registration of folding range.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#foldingRangeRegistrationOptions
+type FoldingRangeRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	FoldingRangeOptions
+	StaticRegistrationOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (FoldingRangeRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentFoldingRange
+}
+
+// FoldingRangeKind represents the kind of a folding range, used to offer
+// commands such as "fold all comments".
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#foldingRangeKind
+type FoldingRangeKind = string
+
+const (
+	FoldingRangeKindComment FoldingRangeKind = "comment"
+	FoldingRangeKindImports FoldingRangeKind = "imports"
+	FoldingRangeKindRegion  FoldingRangeKind = "region"
+)
+
+// FoldingRangeParams are the parameters of the textDocument/foldingRange
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#foldingRangeParams
+type FoldingRangeParams struct {
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// TextDocument is the document to compute folding ranges for.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+// FoldingRange represents a folding range. Lines are zero-based, and the
+// range must lie within the document.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#foldingRange
+type FoldingRange struct {
+	// StartLine is the line the folded range starts on. The first line
+	// stays visible when folded.
+	StartLine UInteger `json:"startLine"`
+
+	// StartCharacter is the character the folded range starts at. If
+	// nil, it is the length of the start line.
+	StartCharacter *UInteger `json:"startCharacter,omitempty"`
+
+	// EndLine is the line the folded range ends on.
+	EndLine UInteger `json:"endLine"`
+
+	// EndCharacter is the character the folded range ends at. If nil, it
+	// is the length of the end line.
+	EndCharacter *UInteger `json:"endCharacter,omitempty"`
+
+	// Kind is the kind of the range, such as FoldingRangeKindComment.
+	Kind FoldingRangeKind `json:"kind,omitempty"`
+
+	// CollapsedText is shown instead of the folded range, if the client
+	// supports it. Otherwise the client picks a default.
+	CollapsedText string `json:"collapsedText,omitempty"`
+}
+
+// FoldingRangesForClient adapts ranges to the folding range capabilities in
+// caps, modifying and returning it: start and end characters are removed if
+// the client folds whole lines only, collapsed texts are removed if the
+// client does not support them, and ranges beyond the client's rangeLimit
+// are dropped. caps may be nil.
+func FoldingRangesForClient(ranges []FoldingRange, caps *ClientCapabilities) []FoldingRange {
+	var fc *FoldingRangeClientCapabilities
+	if caps != nil && caps.TextDocument != nil {
+		fc = caps.TextDocument.FoldingRange
+	}
+	if fc == nil {
+		fc = &FoldingRangeClientCapabilities{}
+	}
+
+	if fc.RangeLimit > 0 && UInteger(len(ranges)) > fc.RangeLimit {
+		ranges = ranges[:fc.RangeLimit]
+	}
+	collapsedText := fc.FoldingRange != nil && fc.FoldingRange.CollapsedText
+	for i := range ranges {
+		if fc.LineFoldingOnly {
+			ranges[i].StartCharacter = nil
+			ranges[i].EndCharacter = nil
+		}
+		if !collapsedText {
+			ranges[i].CollapsedText = ""
+		}
+	}
+	return ranges
+}
@@ -0,0 +1,253 @@
+package golsptoolkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Methods of document formatting.
+const (
+	MethodTextDocumentFormatting       = "textDocument/formatting"
+	MethodTextDocumentRangeFormatting  = "textDocument/rangeFormatting"
+	MethodTextDocumentOnTypeFormatting = "textDocument/onTypeFormatting"
+)
+
+// DocumentFormattingOptions represents the document formatting options a
+// server announces in ServerCapabilities.DocumentFormattingProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentFormattingOptions
+type DocumentFormattingOptions struct {
+	WorkDoneProgressOptions
+}
+
+// DocumentFormattingRegistrationOptions are the options of a dynamic
+// registration of document formatting.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentFormattingRegistrationOptions
+type DocumentFormattingRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	DocumentFormattingOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (DocumentFormattingRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentFormatting
+}
+
+// DocumentFormattingParams are the parameters of the textDocument/formatting
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentFormattingParams
+type DocumentFormattingParams struct {
+	WorkDoneProgressParams
+
+	// TextDocument is the document to format.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+
+	// Options are the formatting options.
+	Options FormattingOptions `json:"options"`
+}
+
+// DocumentRangeFormattingOptions represents the document range formatting
+// options a server announces in
+// ServerCapabilities.DocumentRangeFormattingProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentRangeFormattingOptions
+type DocumentRangeFormattingOptions struct {
+	WorkDoneProgressOptions
+}
+
+// DocumentRangeFormattingRegistrationOptions are the options of a dynamic
+// registration of document range formatting.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentRangeFormattingRegistrationOptions
+type DocumentRangeFormattingRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	DocumentRangeFormattingOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (DocumentRangeFormattingRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentRangeFormatting
+}
+
+// DocumentRangeFormattingParams are the parameters of the
+// textDocument/rangeFormatting request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentRangeFormattingParams
+type DocumentRangeFormattingParams struct {
+	WorkDoneProgressParams
+
+	// TextDocument is the document to format.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+
+	// Range is the range to format.
+	Range Range `json:"range"`
+
+	// Options are the formatting options.
+	Options FormattingOptions `json:"options"`
+}
+
+// DocumentOnTypeFormattingOptions represents the on-type formatting options
+// a server announces in ServerCapabilities.DocumentOnTypeFormattingProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentOnTypeFormattingOptions
+type DocumentOnTypeFormattingOptions struct {
+	// FirstTriggerCharacter is a character on which formatting is
+	// triggered, such as "}".
+	FirstTriggerCharacter string `json:"firstTriggerCharacter"`
+
+	// MoreTriggerCharacter are more trigger characters.
+	MoreTriggerCharacter []string `json:"moreTriggerCharacter,omitempty"`
+}
+
+// DocumentOnTypeFormattingRegistrationOptions are the options of a dynamic
+// registration of on type formatting.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentOnTypeFormattingRegistrationOptions
+type DocumentOnTypeFormattingRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	DocumentOnTypeFormattingOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (DocumentOnTypeFormattingRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentOnTypeFormatting
+}
+
+// DocumentOnTypeFormattingParams are the parameters of the
+// textDocument/onTypeFormatting request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentOnTypeFormattingParams
+type DocumentOnTypeFormattingParams struct {
+	TextDocumentPositionParams
+
+	// Ch is the character that was typed. It is not necessarily the last
+	// character before Position, since the client may have inserted more,
+	// such as a closing brace.
+	Ch string `json:"ch"`
+
+	// Options are the formatting options.
+	Options FormattingOptions `json:"options"`
+}
+
+// FormattingOptions represents the options of a formatting request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#formattingOptions
+type FormattingOptions struct {
+	// TabSize is the size of a tab in spaces.
+	TabSize UInteger
+
+	// InsertSpaces prefers spaces over tabs.
+	InsertSpaces bool
+
+	// TrimTrailingWhitespace trims trailing whitespace on a line.
+	TrimTrailingWhitespace bool
+
+	// InsertFinalNewline inserts a newline character at the end of the
+	// file if one does not exist.
+	InsertFinalNewline bool
+
+	// TrimFinalNewlines trims all newlines after the final newline at the
+	// end of the file.
+	TrimFinalNewlines bool
+
+	// Extra holds further properties, by name. Their values must be a
+	// bool, an Integer or a string.
+	Extra map[string]LSPAny
+}
+
+// formattingOptions holds the properties of FormattingOptions defined by
+// the specification.
+type formattingOptions struct {
+	TabSize                UInteger `json:"tabSize"`
+	InsertSpaces           bool     `json:"insertSpaces"`
+	TrimTrailingWhitespace bool     `json:"trimTrailingWhitespace,omitempty"`
+	InsertFinalNewline     bool     `json:"insertFinalNewline,omitempty"`
+	TrimFinalNewlines      bool     `json:"trimFinalNewlines,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. Extra properties are sent alongside
+// the defined ones; they cannot override them.
+func (o FormattingOptions) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(formattingOptions{o.TabSize, o.InsertSpaces, o.TrimTrailingWhitespace, o.InsertFinalNewline, o.TrimFinalNewlines})
+	if err != nil || len(o.Extra) == 0 {
+		return b, err
+	}
+
+	m := make(map[string]LSPAny, len(o.Extra))
+	for k, v := range o.Extra {
+		switch v.(type) {
+		case bool, string, Integer, int:
+		default:
+			return nil, fmt.Errorf("golsptoolkit: formatting option %q is a %T: must be a bool, an integer or a string", k, v)
+		}
+		m[k] = v
+	}
+	var defined map[string]LSPAny
+	if err := json.Unmarshal(b, &defined); err != nil {
+		return nil, err
+	}
+	for k, v := range defined {
+		m[k] = v
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Extra properties are decoded
+// into Extra as bool, Integer or string values.
+func (o *FormattingOptions) UnmarshalJSON(data []byte) error {
+	var defined formattingOptions
+	if err := json.Unmarshal(data, &defined); err != nil {
+		return err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+
+	*o = FormattingOptions{
+		TabSize:                defined.TabSize,
+		InsertSpaces:           defined.InsertSpaces,
+		TrimTrailingWhitespace: defined.TrimTrailingWhitespace,
+		InsertFinalNewline:     defined.InsertFinalNewline,
+		TrimFinalNewlines:      defined.TrimFinalNewlines,
+	}
+	for k, raw := range all {
+		switch k {
+		case "tabSize", "insertSpaces", "trimTrailingWhitespace", "insertFinalNewline", "trimFinalNewlines":
+			continue
+		}
+		v, err := decodeFormattingOption(raw)
+		if err != nil {
+			return fmt.Errorf("golsptoolkit: formatting option %q: %w", k, err)
+		}
+		if o.Extra == nil {
+			o.Extra = make(map[string]LSPAny)
+		}
+		o.Extra[k] = v
+	}
+	return nil
+}
+
+// decodeFormattingOption decodes the value of an extra formatting option.
+func decodeFormattingOption(raw json.RawMessage) (LSPAny, error) {
+	raw = bytes.TrimSpace(raw)
+	switch {
+	case bytes.Equal(raw, []byte("true")):
+		return true, nil
+	case bytes.Equal(raw, []byte("false")):
+		return false, nil
+	case len(raw) > 0 && raw[0] == '"':
+		var s string
+		err := json.Unmarshal(raw, &s)
+		return s, err
+	}
+	n, err := strconv.ParseInt(string(raw), 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("value %s must be a bool, an integer or a string", raw)
+	}
+	return Integer(n), nil
+}
@@ -0,0 +1,101 @@
+package golsptoolkit
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// globCache holds compiled glob patterns, keyed by globKey.
+var globCache sync.Map
+
+type globKey struct {
+	pattern    string
+	ignoreCase bool
+}
+
+// globMatch reports whether path matches the LSP glob pattern. The syntax
+// supports "*" matching within a path segment, "**" matching any number of
+// segments, "?" matching a single character, "{a,b}" alternatives, and
+// "[a-z]" and "[!a-z]" character ranges. An invalid pattern matches
+// nothing.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#pattern
+func globMatch(pattern, path string, ignoreCase bool) bool {
+	key := globKey{pattern, ignoreCase}
+	re, ok := globCache.Load(key)
+	if !ok {
+		re, _ = globCache.LoadOrStore(key, compileGlob(pattern, ignoreCase))
+	}
+	r := re.(*regexp.Regexp)
+	return r != nil && r.MatchString(path)
+}
+
+// compileGlob translates a glob pattern into an anchored regular
+// expression. It returns nil if the pattern is invalid.
+func compileGlob(pattern string, ignoreCase bool) *regexp.Regexp {
+	var b strings.Builder
+	if ignoreCase {
+		b.WriteString("(?i)")
+	}
+	b.WriteString("^")
+
+	depth := 0
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '{':
+			depth++
+			b.WriteString("(?:")
+		case '}':
+			if depth == 0 {
+				b.WriteString(`\}`)
+				continue
+			}
+			depth--
+			b.WriteString(")")
+		case ',':
+			if depth > 0 {
+				b.WriteString("|")
+			} else {
+				b.WriteString(",")
+			}
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				return nil
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if depth != 0 {
+		return nil
+	}
+	b.WriteString("$")
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil
+	}
+	return re
+}
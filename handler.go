@@ -0,0 +1,93 @@
+package golsptoolkit
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync/atomic"
+)
+
+// Request is an incoming request or notification delivered to a Handler.
+type Request struct {
+	// ID identifies a request. It is nil for notifications.
+	ID *ID
+
+	Method string
+
+	// Params holds the parameters as received, to be decoded by the
+	// handler into the type it expects, as typed handlers do. It is nil
+	// if the message had none.
+	Params json.RawMessage
+}
+
+// IsNotification reports whether r is a notification, which must not be
+// answered.
+func (r *Request) IsNotification() bool {
+	return r.ID == nil
+}
+
+// Replier sends the response to a request: result on success, or err as the
+// response error. An err that is, or wraps, a *ResponseError is sent as is;
+// context.Canceled is sent as RequestCancelled; any other error is sent as an
+// InternalError carrying its message.
+//
+// For notifications nothing is sent; a non-nil err is logged by the
+// connection instead.
+type Replier func(ctx context.Context, result any, err error) error
+
+// Handler responds to incoming requests and notifications.
+//
+// Handle is called with a context that is canceled when the connection
+// closes, and for requests also when the peer cancels the request with
+// $/cancelRequest. For requests, Handle must call reply exactly once to send
+// the response, either before returning or, after calling Detach, later.
+type Handler interface {
+	Handle(ctx context.Context, reply Replier, req *Request)
+}
+
+// HandlerFunc adapts an ordinary function to a Handler.
+type HandlerFunc func(ctx context.Context, reply Replier, req *Request)
+
+// Handle calls f(ctx, reply, req).
+func (f HandlerFunc) Handle(ctx context.Context, reply Replier, req *Request) {
+	f(ctx, reply, req)
+}
+
+// requestStateKey is the context key under which JSONRPCConn stores the
+// requestState of the request being handled.
+type requestStateKey struct{}
+
+// requestState tracks what a handler has done with its request.
+type requestState struct {
+	detached atomic.Bool
+
+	// conn is the connection the request arrived on.
+	conn *JSONRPCConn
+}
+
+// logf logs through the ErrorLog of the connection handling the request of
+// ctx, or through the standard logger if ctx belongs to none.
+func logf(ctx context.Context, format string, args ...any) {
+	if st, ok := ctx.Value(requestStateKey{}).(*requestState); ok && st.conn != nil {
+		st.conn.logf(format, args...)
+		return
+	}
+	log.Printf("golsptoolkit: "+format, args...)
+}
+
+// Detach marks the request whose handler received ctx as answered
+// asynchronously: the handler may return without replying, and call reply
+// later from another goroutine, for example once a background analysis has
+// finished. Cancellation and the single-reply rule still apply, and ctx
+// stays valid until the reply is sent or the request is canceled.
+//
+// Detach reports false if ctx does not belong to a request being handled by
+// a JSONRPCConn, such as a notification.
+func Detach(ctx context.Context) bool {
+	st, ok := ctx.Value(requestStateKey{}).(*requestState)
+	if !ok {
+		return false
+	}
+	st.detached.Store(true)
+	return true
+}
@@ -0,0 +1,217 @@
+package golsptoolkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// Methods of hover.
+const (
+	MethodTextDocumentHover = "textDocument/hover"
+)
+
+// HoverOptions represents the hover options a server announces in
+// ServerCapabilities.HoverProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#hoverOptions
+type HoverOptions struct {
+	WorkDoneProgressOptions
+}
+
+// HoverRegistrationOptions are the options of a dynamic registration of
+// hover.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#hoverRegistrationOptions
+type HoverRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	HoverOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (HoverRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentHover
+}
+
+// MarkupKind represents the format of a MarkupContent value. Clients list the
+// kinds they support in their capabilities.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#markupContentInnerDefinition
+type MarkupKind = string
+
+const (
+	MarkupKindPlainText MarkupKind = "plaintext"
+	MarkupKindMarkdown  MarkupKind = "markdown"
+)
+
+// MarkupContent represents a string value whose content is interpreted
+// according to Kind.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#markupContentInnerDefinition
+type MarkupContent struct {
+	Kind  MarkupKind `json:"kind"`
+	Value string     `json:"value"`
+}
+
+// MarkedString represents Markdown text, or a code block in Language if
+// Language is set. It encodes as a plain string when Language is empty, and
+// as an object with language and value properties otherwise.
+//
+// Deprecated: Use MarkupContent instead. MarkedString remains for clients
+// that do not support MarkupContent.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#markedString
+type MarkedString struct {
+	Language string
+	Value    string
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s MarkedString) MarshalJSON() ([]byte, error) {
+	if s.Language == "" {
+		return json.Marshal(s.Value)
+	}
+	return json.Marshal(struct {
+		Language string `json:"language"`
+		Value    string `json:"value"`
+	}{s.Language, s.Value})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts a string or an
+// object with language and value properties.
+func (s *MarkedString) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		*s = MarkedString{}
+		return json.Unmarshal(data, &s.Value)
+	}
+	var v struct {
+		Language string `json:"language"`
+		Value    string `json:"value"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*s = MarkedString{Language: v.Language, Value: v.Value}
+	return nil
+}
+
+// markdown returns s as Markdown, fencing code blocks.
+func (s MarkedString) markdown() string {
+	if s.Language == "" {
+		return s.Value
+	}
+	return "```" + s.Language + "\n" + s.Value + "\n```"
+}
+
+// HoverParams are the parameters of the textDocument/hover request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#hoverParams
+type HoverParams struct {
+	TextDocumentPositionParams
+	WorkDoneProgressParams
+}
+
+// Hover is the result of the textDocument/hover request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#hover
+type Hover struct {
+	// Contents are the contents of the hover.
+	Contents HoverContents `json:"contents"`
+
+	// Range is the range the hover applies to, used to visualize it, such
+	// as by changing the background color.
+	Range *Range `json:"range,omitempty"`
+}
+
+// NewHover returns a hover showing content, in a form the client described
+// by caps understands: a client that announces no hover content formats
+// predates MarkupContent and receives the value as a MarkedString, which it
+// renders as Markdown. caps may be nil.
+func NewHover(content MarkupContent, caps *ClientCapabilities) Hover {
+	if caps == nil || caps.TextDocument == nil || caps.TextDocument.Hover == nil || len(caps.TextDocument.Hover.ContentFormat) == 0 {
+		value := content.Value
+		if content.Kind == MarkupKindPlainText {
+			value = "```\n" + value + "\n```"
+		}
+		return Hover{Contents: HoverContents{MarkedStrings: []MarkedString{{Value: value}}}}
+	}
+	return Hover{Contents: HoverContents{Markup: &content}}
+}
+
+// errHoverContents is returned when the contents of a hover are neither a
+// MarkupContent nor MarkedStrings.
+var errHoverContents = errors.New("golsptoolkit: hover contents must be a MarkupContent, a MarkedString, or an array of MarkedString")
+
+// HoverContents holds the contents of a hover: either a MarkupContent, or,
+// for clients that predate it, one or more MarkedStrings.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#hover
+type HoverContents struct {
+	// Markup is the content, if it is a MarkupContent.
+	Markup *MarkupContent
+
+	// MarkedStrings is the content if Markup is nil. A single MarkedString
+	// is encoded on its own, several as an array.
+	MarkedStrings []MarkedString
+}
+
+// MarkupContent returns the contents as a MarkupContent, converting
+// MarkedStrings to Markdown paragraphs.
+func (c HoverContents) MarkupContent() MarkupContent {
+	if c.Markup != nil {
+		return *c.Markup
+	}
+	parts := make([]string, len(c.MarkedStrings))
+	for i, s := range c.MarkedStrings {
+		parts[i] = s.markdown()
+	}
+	return MarkupContent{Kind: MarkupKindMarkdown, Value: strings.Join(parts, "\n\n")}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c HoverContents) MarshalJSON() ([]byte, error) {
+	switch {
+	case c.Markup != nil:
+		return json.Marshal(c.Markup)
+	case len(c.MarkedStrings) == 1:
+		return json.Marshal(c.MarkedStrings[0])
+	case c.MarkedStrings == nil:
+		return []byte("[]"), nil
+	}
+	return json.Marshal(c.MarkedStrings)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts a MarkupContent, a
+// MarkedString, or an array of MarkedString.
+func (c *HoverContents) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return errHoverContents
+	}
+	*c = HoverContents{}
+	switch data[0] {
+	case '[':
+		return json.Unmarshal(data, &c.MarkedStrings)
+	case '"':
+		c.MarkedStrings = make([]MarkedString, 1)
+		return json.Unmarshal(data, &c.MarkedStrings[0])
+	case '{':
+		var v struct {
+			Kind     *MarkupKind `json:"kind"`
+			Language string      `json:"language"`
+			Value    string      `json:"value"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		if v.Kind != nil {
+			c.Markup = &MarkupContent{Kind: *v.Kind, Value: v.Value}
+		} else {
+			c.MarkedStrings = []MarkedString{{Language: v.Language, Value: v.Value}}
+		}
+		return nil
+	}
+	return errHoverContents
+}
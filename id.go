@@ -0,0 +1,108 @@
+package golsptoolkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// intOrString holds a value of the integer | string union used for request
+// ids and progress tokens. It keeps the representation it was created or
+// decoded with and encodes it back unchanged.
+type intOrString struct {
+	num      int64
+	str      string
+	isString bool
+}
+
+// IsString reports whether the value is a string.
+func (v intOrString) IsString() bool {
+	return v.isString
+}
+
+// Raw returns the value as an int64 or a string.
+func (v intOrString) Raw() any {
+	if v.isString {
+		return v.str
+	}
+	return v.num
+}
+
+// String formats the value for display. Strings are quoted so that they can
+// be told apart from integers.
+func (v intOrString) String() string {
+	if v.isString {
+		return strconv.Quote(v.str)
+	}
+	return strconv.FormatInt(v.num, 10)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v intOrString) MarshalJSON() ([]byte, error) {
+	if v.isString {
+		return json.Marshal(v.str)
+	}
+	return strconv.AppendInt(nil, v.num, 10), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts integers and
+// strings.
+func (v *intOrString) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*v = intOrString{str: s, isString: true}
+		return nil
+	}
+
+	n, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return fmt.Errorf("golsptoolkit: invalid value %s: must be an integer or a string", data)
+	}
+	*v = intOrString{num: n}
+	return nil
+}
+
+// ID identifies a request. The specification allows both integer and string
+// ids, and clients use both, so ID keeps the representation it was created
+// or decoded with and encodes it back unchanged.
+//
+// IDs are comparable: an integer id never equals a string id, even when the
+// string holds the same digits.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#requestMessage
+type ID struct {
+	intOrString
+}
+
+// NewIntID returns an integer request id.
+func NewIntID(n int64) ID {
+	return ID{intOrString{num: n}}
+}
+
+// NewStringID returns a string request id.
+func NewStringID(s string) ID {
+	return ID{intOrString{str: s, isString: true}}
+}
+
+// ProgressToken identifies a progress report. Like ID it may be an integer or
+// a string; VS Code, for one, sends UUID strings.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#progress
+type ProgressToken struct {
+	intOrString
+}
+
+// NewIntProgressToken returns an integer progress token.
+func NewIntProgressToken(n int64) ProgressToken {
+	return ProgressToken{intOrString{num: n}}
+}
+
+// NewStringProgressToken returns a string progress token.
+func NewStringProgressToken(s string) ProgressToken {
+	return ProgressToken{intOrString{str: s, isString: true}}
+}
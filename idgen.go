@@ -0,0 +1,53 @@
+package golsptoolkit
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+)
+
+// IDGenerator produces the ids of outgoing requests. Ids must be unique for
+// the lifetime of a connection; NextID may be called concurrently.
+type IDGenerator interface {
+	NextID() ID
+}
+
+// IntIDGenerator produces the integer ids 1, 2, 3, and so on. It is the
+// default of JSONRPCConn. The zero value is ready to use.
+type IntIDGenerator struct {
+	n atomic.Int64
+}
+
+// NextID implements IDGenerator.
+func (g *IntIDGenerator) NextID() ID {
+	return NewIntID(g.n.Add(1))
+}
+
+// PrefixIDGenerator produces string ids made of Prefix followed by a
+// counter, such as "proxy-1", "proxy-2". Proxies merging traffic from
+// several sources give each source its own prefix so that ids never
+// collide. The zero value produces "1", "2", and so on.
+type PrefixIDGenerator struct {
+	Prefix string
+
+	n atomic.Int64
+}
+
+// NextID implements IDGenerator.
+func (g *PrefixIDGenerator) NextID() ID {
+	return NewStringID(g.Prefix + strconv.FormatInt(g.n.Add(1), 10))
+}
+
+// UUIDGenerator produces random version 4 UUIDs as string ids, unique
+// without any coordination between their sources.
+type UUIDGenerator struct{}
+
+// NextID implements IDGenerator.
+func (UUIDGenerator) NextID() ID {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return NewStringID(fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]))
+}
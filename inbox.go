@@ -0,0 +1,76 @@
+package golsptoolkit
+
+import (
+	"context"
+	"sync"
+)
+
+// inbox is an unbounded queue of incoming messages for transports whose
+// messages are delivered by another goroutine. Pushing never blocks, so one
+// slow reader cannot stall the goroutine feeding it.
+type inbox struct {
+	mu     sync.Mutex
+	queue  [][]byte
+	err    error
+	notify chan struct{}
+}
+
+func newInbox() *inbox {
+	return &inbox{notify: make(chan struct{}, 1)}
+}
+
+func (in *inbox) push(body []byte) {
+	in.mu.Lock()
+	in.queue = append(in.queue, body)
+	in.mu.Unlock()
+	in.wake()
+}
+
+// close makes ReadMessage return err once the queue has drained. Only the
+// first error is kept.
+func (in *inbox) close(err error) {
+	in.mu.Lock()
+	if in.err == nil {
+		in.err = err
+	}
+	in.mu.Unlock()
+	in.wake()
+}
+
+// closed returns the error passed to close, or nil.
+func (in *inbox) closed() error {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return in.err
+}
+
+func (in *inbox) wake() {
+	select {
+	case in.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (in *inbox) ReadMessage(ctx context.Context) ([]byte, error) {
+	for {
+		in.mu.Lock()
+		if len(in.queue) > 0 {
+			body := in.queue[0]
+			in.queue[0] = nil
+			in.queue = in.queue[1:]
+			in.mu.Unlock()
+			return body, nil
+		}
+		err := in.err
+		in.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+
+		select {
+		case <-in.notify:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package golsptoolkit
+
+import "context"
+
+// Methods of inlay hints.
+const (
+	MethodTextDocumentInlayHint     = "textDocument/inlayHint"
+	MethodInlayHintResolve          = "inlayHint/resolve"
+	MethodWorkspaceInlayHintRefresh = "workspace/inlayHint/refresh"
+)
+
+// InlayHintOptions represents the inlay hint options a server announces in
+// ServerCapabilities.InlayHintProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#inlayHintOptions
+type InlayHintOptions struct {
+	WorkDoneProgressOptions
+
+	// ResolveProvider reports whether the server computes additional
+	// properties of inlay hints with inlayHint/resolve.
+	ResolveProvider bool `json:"resolveProvider,omitempty"`
+}
+
+// InlayHintRegistrationOptions are the options of a dynamic or static
+// registration of inlay hints.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#inlayHintRegistrationOptions
+type InlayHintRegistrationOptions struct {
+	InlayHintOptions
+	TextDocumentRegistrationOptions
+	StaticRegistrationOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (InlayHintRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentInlayHint
+}
+
+// InlayHintParams are the parameters of the textDocument/inlayHint request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#inlayHintParams
+type InlayHintParams struct {
+	WorkDoneProgressParams
+
+	// TextDocument is the document.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+
+	// Range is the visible range to compute hints for.
+	Range Range `json:"range"`
+}
+
+// InlayHintKind represents the kind of an inlay hint.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#inlayHintKind
+type InlayHintKind UInteger
+
+const (
+	// InlayHintKindType is a hint for a type annotation.
+	InlayHintKindType InlayHintKind = 1
+
+	// InlayHintKindParameter is a hint for a parameter name.
+	InlayHintKindParameter InlayHintKind = 2
+)
+
+var inlayHintKindNames = enumNames{"InlayHintKind", []string{
+	InlayHintKindType:      "Type",
+	InlayHintKindParameter: "Parameter",
+}}
+
+// String returns the name of k, such as "Type".
+func (k InlayHintKind) String() string {
+	return inlayHintKindNames.name(int64(k))
+}
+
+// IsValid reports whether k is one of the defined inlay hint kinds.
+func (k InlayHintKind) IsValid() bool {
+	return inlayHintKindNames.valid(int64(k))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Undefined values are rejected.
+func (k *InlayHintKind) UnmarshalJSON(data []byte) error {
+	return decodeEnum(data, k, inlayHintKindNames)
+}
+
+// InlayHint represents an inlay hint, a piece of text shown inline in the
+// editor, such as an inferred type.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#inlayHint
+type InlayHint struct {
+	// Position is where the hint is shown. If several hints share a
+	// position, they are shown in the order of the result.
+	Position Position `json:"position"`
+
+	// Label is the label of the hint.
+	Label InlayHintLabel `json:"label"`
+
+	// Kind is the kind of the hint, used for styling.
+	Kind InlayHintKind `json:"kind,omitempty"`
+
+	// TextEdits are applied when the hint is accepted, typically making
+	// the hint part of the document.
+	TextEdits []TextEdit `json:"textEdits,omitempty"`
+
+	// Tooltip is shown when hovering over the hint.
+	Tooltip Or2[string, MarkupContent] `json:"tooltip,omitzero"`
+
+	// PaddingLeft renders padding before the hint.
+	PaddingLeft bool `json:"paddingLeft,omitempty"`
+
+	// PaddingRight renders padding after the hint.
+	PaddingRight bool `json:"paddingRight,omitempty"`
+
+	// Data is preserved by the client between a textDocument/inlayHint and
+	// an inlayHint/resolve request.
+	Data LSPAny `json:"data,omitempty"`
+}
+
+// InlayHintLabel is the label of an inlay hint: a string, or label parts
+// that can each have their own tooltip, location and command.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#inlayHint
+type InlayHintLabel = Or2[string, []InlayHintLabelPart]
+
+// NewInlayHintLabel returns a label made of the string label.
+func NewInlayHintLabel(label string) InlayHintLabel {
+	return NewOr2A[string, []InlayHintLabelPart](label)
+}
+
+// NewInlayHintLabelParts returns a label made of parts.
+func NewInlayHintLabelParts(parts ...InlayHintLabelPart) InlayHintLabel {
+	return NewOr2B[string](parts)
+}
+
+// InlayHintLabelPart represents a part of the label of an inlay hint.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#inlayHintLabelPart
+type InlayHintLabelPart struct {
+	// Value is the text of the part.
+	Value string `json:"value"`
+
+	// Tooltip is shown when hovering over the part.
+	Tooltip Or2[string, MarkupContent] `json:"tooltip,omitzero"`
+
+	// Location makes the part a link to the location, such as the
+	// declaration of a type.
+	Location *Location `json:"location,omitempty"`
+
+	// Command is run when the part is clicked.
+	Command *Command `json:"command,omitempty"`
+}
+
+// RefreshInlayHints asks the client to refresh the inlay hints of all
+// documents with the workspace/inlayHint/refresh request. It does nothing if
+// caps, the client's capabilities, do not announce refreshSupport.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspace_inlayHint_refresh
+func RefreshInlayHints(ctx context.Context, conn *JSONRPCConn, caps *ClientCapabilities) error {
+	if caps == nil || caps.Workspace == nil || caps.Workspace.InlayHint == nil || !caps.Workspace.InlayHint.RefreshSupport {
+		return nil
+	}
+	return conn.Call(ctx, MethodWorkspaceInlayHintRefresh, nil, nil)
+}
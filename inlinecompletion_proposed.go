@@ -0,0 +1,161 @@
+//go:build golsptoolkit_proposed
+
+package golsptoolkit
+
+// This file holds the inline completion types proposed for version 3.18 of
+// the protocol. They may change before the version is released and are only
+// built with the golsptoolkit_proposed build tag.
+
+// Methods of inline completion.
+const (
+	MethodTextDocumentInlineCompletion = "textDocument/inlineCompletion"
+)
+
+// InlineCompletionClientCapabilities are the client capabilities for the
+// textDocument/inlineCompletion request, sent in the inlineCompletion
+// property of the text document client capabilities.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.18/specification/#inlineCompletionClientCapabilities
+type InlineCompletionClientCapabilities struct {
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
+
+// InlineCompletionOptions represents the inline completion options a server
+// announces in the inlineCompletionProvider property of its capabilities.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.18/specification/#inlineCompletionOptions
+type InlineCompletionOptions struct {
+	WorkDoneProgressOptions
+}
+
+// InlineCompletionRegistrationOptions are the options of a dynamic
+// registration of inline completion.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.18/specification/#inlineCompletionRegistrationOptions
+type InlineCompletionRegistrationOptions struct {
+	InlineCompletionOptions
+	TextDocumentRegistrationOptions
+	StaticRegistrationOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (InlineCompletionRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentInlineCompletion
+}
+
+// InlineCompletionParams are the parameters of the
+// textDocument/inlineCompletion request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.18/specification/#inlineCompletionParams
+type InlineCompletionParams struct {
+	TextDocumentPositionParams
+	WorkDoneProgressParams
+
+	// Context describes how the request was triggered.
+	Context InlineCompletionContext `json:"context"`
+}
+
+// InlineCompletionContext describes how an inline completion request was
+// triggered.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.18/specification/#inlineCompletionContext
+type InlineCompletionContext struct {
+	// TriggerKind is how the request was triggered.
+	TriggerKind InlineCompletionTriggerKind `json:"triggerKind"`
+
+	// SelectedCompletionInfo describes the item selected in the completion
+	// widget, if it is visible.
+	SelectedCompletionInfo *SelectedCompletionInfo `json:"selectedCompletionInfo,omitempty"`
+}
+
+// InlineCompletionTriggerKind represents how an inline completion request
+// was triggered.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.18/specification/#inlineCompletionTriggerKind
+type InlineCompletionTriggerKind UInteger
+
+const (
+	// InlineCompletionTriggerKindInvoked means the user explicitly asked
+	// for inline completions.
+	InlineCompletionTriggerKindInvoked InlineCompletionTriggerKind = 1
+
+	// InlineCompletionTriggerKindAutomatic means the request was sent
+	// automatically while the user was typing.
+	InlineCompletionTriggerKindAutomatic InlineCompletionTriggerKind = 2
+)
+
+var inlineCompletionTriggerKindNames = enumNames{"InlineCompletionTriggerKind", []string{
+	InlineCompletionTriggerKindInvoked:   "Invoked",
+	InlineCompletionTriggerKindAutomatic: "Automatic",
+}}
+
+// String returns the name of k, such as "Invoked".
+func (k InlineCompletionTriggerKind) String() string {
+	return inlineCompletionTriggerKindNames.name(int64(k))
+}
+
+// IsValid reports whether k is one of the defined inline completion trigger kinds.
+func (k InlineCompletionTriggerKind) IsValid() bool {
+	return inlineCompletionTriggerKindNames.valid(int64(k))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Undefined values are rejected.
+func (k *InlineCompletionTriggerKind) UnmarshalJSON(data []byte) error {
+	return decodeEnum(data, k, inlineCompletionTriggerKindNames)
+}
+
+// SelectedCompletionInfo describes the item selected in the completion
+// widget.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.18/specification/#selectedCompletionInfo
+type SelectedCompletionInfo struct {
+	// Range is the range replaced if the item is accepted.
+	Range Range `json:"range"`
+
+	// Text is the text the range is replaced with.
+	Text string `json:"text"`
+}
+
+// InlineCompletionList is the result of the textDocument/inlineCompletion
+// request, which may also be a bare array of items.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.18/specification/#inlineCompletionList
+type InlineCompletionList struct {
+	Items []InlineCompletionItem `json:"items"`
+}
+
+// InlineCompletionItem represents an inline completion proposal.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.18/specification/#inlineCompletionItem
+type InlineCompletionItem struct {
+	// InsertText is the text replacing Range, or a snippet.
+	InsertText Or2[string, StringValue] `json:"insertText"`
+
+	// FilterText is used instead of InsertText to decide whether the item
+	// is still shown as the user types.
+	FilterText string `json:"filterText,omitempty"`
+
+	// Range is the range replaced. If nil, the text is inserted at the
+	// request position.
+	Range *Range `json:"range,omitempty"`
+
+	// Command is run after the item is accepted.
+	Command *Command `json:"command,omitempty"`
+}
+
+// StringValue is a string with a kind, used for snippets.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.18/specification/#stringValue
+type StringValue struct {
+	// Kind is always "snippet".
+	Kind string `json:"kind"`
+
+	// Value is the snippet, with tab stops such as $1 and placeholders
+	// such as ${1:name}.
+	Value string `json:"value"`
+}
+
+// NewSnippetStringValue returns a StringValue holding snippet.
+func NewSnippetStringValue(snippet string) StringValue {
+	return StringValue{Kind: "snippet", Value: snippet}
+}
@@ -0,0 +1,169 @@
+package golsptoolkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// Methods of inline values.
+const (
+	MethodTextDocumentInlineValue     = "textDocument/inlineValue"
+	MethodWorkspaceInlineValueRefresh = "workspace/inlineValue/refresh"
+)
+
+// InlineValueOptions represents the inline value options a server announces
+// in ServerCapabilities.InlineValueProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#inlineValueOptions
+type InlineValueOptions struct {
+	WorkDoneProgressOptions
+}
+
+// InlineValueRegistrationOptions are the options of a dynamic or static
+// registration of inline values.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#inlineValueRegistrationOptions
+type InlineValueRegistrationOptions struct {
+	InlineValueOptions
+	TextDocumentRegistrationOptions
+	StaticRegistrationOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (InlineValueRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentInlineValue
+}
+
+// InlineValueParams are the parameters of the textDocument/inlineValue
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#inlineValueParams
+type InlineValueParams struct {
+	WorkDoneProgressParams
+
+	// TextDocument is the document.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+
+	// Range is the visible range to compute inline values for.
+	Range Range `json:"range"`
+
+	// Context describes where the debugger stopped.
+	Context InlineValueContext `json:"context"`
+}
+
+// InlineValueContext describes where the debugger stopped.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#inlineValueContext
+type InlineValueContext struct {
+	// FrameID is the id of the stack frame, as assigned by the debug
+	// adapter.
+	FrameID Integer `json:"frameId"`
+
+	// StoppedLocation is the range where execution stopped, typically the
+	// current line.
+	StoppedLocation Range `json:"stoppedLocation"`
+}
+
+// InlineValueText is an inline value shown as text.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#inlineValueText
+type InlineValueText struct {
+	// Range is the range the value applies to.
+	Range Range `json:"range"`
+
+	// Text is the text of the value.
+	Text string `json:"text"`
+}
+
+// InlineValueVariableLookup is an inline value computed by looking up a
+// variable.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#inlineValueVariableLookup
+type InlineValueVariableLookup struct {
+	// Range is the range the value applies to; also the variable name, if
+	// VariableName is empty.
+	Range Range `json:"range"`
+
+	// VariableName is the name of the variable to look up.
+	VariableName string `json:"variableName,omitempty"`
+
+	// CaseSensitiveLookup reports whether the lookup is case sensitive.
+	CaseSensitiveLookup bool `json:"caseSensitiveLookup"`
+}
+
+// InlineValueEvaluatableExpression is an inline value computed by
+// evaluating an expression.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#inlineValueEvaluatableExpression
+type InlineValueEvaluatableExpression struct {
+	// Range is the range the value applies to; also the expression, if
+	// Expression is empty.
+	Range Range `json:"range"`
+
+	// Expression is the expression to evaluate.
+	Expression string `json:"expression,omitempty"`
+}
+
+// InlineValue is an inline value: exactly one of its fields is set. It is
+// not an Or3, since its variants are told apart by their required
+// properties, which Or3 does not check.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#inlineValue
+type InlineValue struct {
+	Text                  *InlineValueText
+	VariableLookup        *InlineValueVariableLookup
+	EvaluatableExpression *InlineValueEvaluatableExpression
+}
+
+// errInlineValue is returned when an InlineValue has no field set.
+var errInlineValue = errors.New("golsptoolkit: inline value has no value set")
+
+// MarshalJSON implements json.Marshaler.
+func (v InlineValue) MarshalJSON() ([]byte, error) {
+	switch {
+	case v.Text != nil:
+		return json.Marshal(v.Text)
+	case v.VariableLookup != nil:
+		return json.Marshal(v.VariableLookup)
+	case v.EvaluatableExpression != nil:
+		return json.Marshal(v.EvaluatableExpression)
+	}
+	return nil, errInlineValue
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The variant is told apart by
+// the text and caseSensitiveLookup properties, which are required in their
+// variants; a value with neither is an evaluatable expression.
+func (v *InlineValue) UnmarshalJSON(data []byte) error {
+	var probe struct {
+		Text                *string `json:"text"`
+		CaseSensitiveLookup *bool   `json:"caseSensitiveLookup"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+	*v = InlineValue{}
+	switch {
+	case probe.Text != nil:
+		v.Text = new(InlineValueText)
+		return json.Unmarshal(data, v.Text)
+	case probe.CaseSensitiveLookup != nil:
+		v.VariableLookup = new(InlineValueVariableLookup)
+		return json.Unmarshal(data, v.VariableLookup)
+	}
+	v.EvaluatableExpression = new(InlineValueEvaluatableExpression)
+	return json.Unmarshal(data, v.EvaluatableExpression)
+}
+
+// RefreshInlineValues asks the client to refresh the inline values of all
+// documents with the workspace/inlineValue/refresh request. It does nothing
+// if caps, the client's capabilities, do not announce refreshSupport.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspace_inlineValue_refresh
+func RefreshInlineValues(ctx context.Context, conn *JSONRPCConn, caps *ClientCapabilities) error {
+	if caps == nil || caps.Workspace == nil || caps.Workspace.InlineValue == nil || !caps.Workspace.InlineValue.RefreshSupport {
+		return nil
+	}
+	return conn.Call(ctx, MethodWorkspaceInlineValueRefresh, nil, nil)
+}
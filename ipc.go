@@ -0,0 +1,48 @@
+package golsptoolkit
+
+import (
+	"context"
+	"net"
+	"strings"
+)
+
+// ListenPipe listens on the local IPC endpoint name: a Unix domain socket path
+// on Linux and macOS, or a named pipe on Windows. Bare Windows names are
+// placed under `\\.\pipe\`.
+func ListenPipe(name string) (net.Listener, error) {
+	return listenPipe(name)
+}
+
+// DialPipe connects to the local IPC endpoint name, as requested by the
+// "--pipe=<name>" launch argument used by VS Code.
+func DialPipe(ctx context.Context, name string) (*StreamConn, error) {
+	nc, err := dialPipe(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return NewNetConn(nc), nil
+}
+
+// ListenAndServePipe listens on the local IPC endpoint name and serves
+// connections with s.Serve.
+func (s *SocketServer) ListenAndServePipe(name string) error {
+	l, err := ListenPipe(name)
+	if err != nil {
+		return err
+	}
+	return s.Serve(l)
+}
+
+// PipeFromArgs looks for a "--pipe" argument in args, accepting both the
+// "--pipe=name" and "--pipe name" forms.
+func PipeFromArgs(args []string) (name string, ok bool) {
+	for i, arg := range args {
+		if value, found := strings.CutPrefix(arg, "--pipe="); found {
+			return value, value != ""
+		}
+		if arg == "--pipe" && i+1 < len(args) {
+			return args[i+1], args[i+1] != ""
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package golsptoolkit
+
+import (
+	"context"
+	"net"
+)
+
+func listenPipe(name string) (net.Listener, error) {
+	return net.Listen("unix", name)
+}
+
+func dialPipe(ctx context.Context, name string) (net.Conn, error) {
+	var d net.Dialer
+	return d.DialContext(ctx, "unix", name)
+}
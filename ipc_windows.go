@@ -0,0 +1,231 @@
+//go:build windows
+
+package golsptoolkit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+var (
+	modkernel32 = syscall.NewLazyDLL("kernel32.dll")
+
+	procCreateNamedPipeW    = modkernel32.NewProc("CreateNamedPipeW")
+	procConnectNamedPipe    = modkernel32.NewProc("ConnectNamedPipe")
+	procDisconnectNamedPipe = modkernel32.NewProc("DisconnectNamedPipe")
+	procWaitNamedPipeW      = modkernel32.NewProc("WaitNamedPipeW")
+	procCancelIoEx          = modkernel32.NewProc("CancelIoEx")
+)
+
+const (
+	pipeAccessDuplex       = 0x3
+	fileFlagFirstInstance  = 0x00080000
+	pipeTypeByte           = 0x0
+	pipeUnlimitedInstances = 255
+	pipeBufferSize         = 64 << 10
+
+	errorPipeBusy      syscall.Errno = 231
+	errorPipeConnected syscall.Errno = 535
+)
+
+// errPipeDeadline is returned by the deadline methods of named pipe
+// connections, which use blocking I/O.
+var errPipeDeadline = errors.New("golsptoolkit: deadlines are not supported on named pipes")
+
+func pipePath(name string) string {
+	if strings.HasPrefix(name, `\\`) {
+		return name
+	}
+	return `\\.\pipe\` + name
+}
+
+type pipeAddr string
+
+func (a pipeAddr) Network() string { return "pipe" }
+func (a pipeAddr) String() string  { return string(a) }
+
+type pipeListener struct {
+	path string
+
+	mu     sync.Mutex
+	closed bool
+
+	// first is the instance created by listenPipe, kept for the first
+	// Accept, or InvalidHandle once taken.
+	first syscall.Handle
+}
+
+func listenPipe(name string) (net.Listener, error) {
+	l := &pipeListener{path: pipePath(name)}
+
+	// Create the first instance up front, as the first instance of the
+	// name, so that an invalid or already-owned name is reported by
+	// ListenPipe rather than Accept. It serves the first Accept, and
+	// keeps the name owned until then.
+	h, err := l.createInstance(fileFlagFirstInstance)
+	if err != nil {
+		return nil, err
+	}
+	l.first = h
+	return l, nil
+}
+
+func (l *pipeListener) createInstance(flags uintptr) (syscall.Handle, error) {
+	path, err := syscall.UTF16PtrFromString(l.path)
+	if err != nil {
+		return syscall.InvalidHandle, err
+	}
+	r, _, err := procCreateNamedPipeW.Call(
+		uintptr(unsafe.Pointer(path)),
+		pipeAccessDuplex|flags,
+		pipeTypeByte,
+		pipeUnlimitedInstances,
+		pipeBufferSize,
+		pipeBufferSize,
+		0,
+		0,
+	)
+	h := syscall.Handle(r)
+	if h == syscall.InvalidHandle {
+		return h, &net.OpError{Op: "listen", Net: "pipe", Addr: pipeAddr(l.path), Err: err}
+	}
+	return h, nil
+}
+
+func (l *pipeListener) Accept() (net.Conn, error) {
+	l.mu.Lock()
+	h := l.first
+	l.first = syscall.InvalidHandle
+	closed := l.closed
+	l.mu.Unlock()
+	if closed {
+		return nil, net.ErrClosed
+	}
+	if h == syscall.InvalidHandle {
+		var err error
+		if h, err = l.createInstance(0); err != nil {
+			return nil, err
+		}
+	}
+
+	r, _, err := procConnectNamedPipe.Call(uintptr(h), 0)
+	if r == 0 && err != errorPipeConnected {
+		syscall.CloseHandle(h)
+		return nil, &net.OpError{Op: "accept", Net: "pipe", Addr: pipeAddr(l.path), Err: err}
+	}
+
+	l.mu.Lock()
+	closed = l.closed
+	l.mu.Unlock()
+	if closed {
+		procDisconnectNamedPipe.Call(uintptr(h))
+		syscall.CloseHandle(h)
+		return nil, net.ErrClosed
+	}
+	return &pipeConn{h: h, path: l.path, server: true}, nil
+}
+
+// Close stops the listener. A pending Accept is woken by connecting to the
+// pipe once, since ConnectNamedPipe cannot otherwise be interrupted.
+func (l *pipeListener) Close() error {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return nil
+	}
+	l.closed = true
+	first := l.first
+	l.first = syscall.InvalidHandle
+	l.mu.Unlock()
+
+	if first != syscall.InvalidHandle {
+		syscall.CloseHandle(first)
+		return nil
+	}
+
+	if h, err := openPipe(l.path); err == nil {
+		syscall.CloseHandle(h)
+	}
+	return nil
+}
+
+func (l *pipeListener) Addr() net.Addr { return pipeAddr(l.path) }
+
+func openPipe(path string) (syscall.Handle, error) {
+	p, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return syscall.InvalidHandle, err
+	}
+	return syscall.CreateFile(p, syscall.GENERIC_READ|syscall.GENERIC_WRITE, 0, nil, syscall.OPEN_EXISTING, 0, 0)
+}
+
+func dialPipe(ctx context.Context, name string) (net.Conn, error) {
+	path := pipePath(name)
+	for {
+		h, err := openPipe(path)
+		if err == nil {
+			return &pipeConn{h: h, path: path}, nil
+		}
+		if err != errorPipeBusy {
+			return nil, &net.OpError{Op: "dial", Net: "pipe", Addr: pipeAddr(path), Err: err}
+		}
+
+		if p, err := syscall.UTF16PtrFromString(path); err == nil {
+			procWaitNamedPipeW.Call(uintptr(unsafe.Pointer(p)), 250)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+	}
+}
+
+type pipeConn struct {
+	h      syscall.Handle
+	path   string
+	server bool
+
+	closeOnce sync.Once
+}
+
+func (c *pipeConn) Read(b []byte) (int, error) {
+	var n uint32
+	err := syscall.ReadFile(c.h, b, &n, nil)
+	if err == syscall.ERROR_BROKEN_PIPE || (err == nil && n == 0 && len(b) > 0) {
+		return 0, io.EOF
+	}
+	return int(n), err
+}
+
+func (c *pipeConn) Write(b []byte) (int, error) {
+	var n uint32
+	err := syscall.WriteFile(c.h, b, &n, nil)
+	return int(n), err
+}
+
+func (c *pipeConn) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		procCancelIoEx.Call(uintptr(c.h), 0)
+		if c.server {
+			syscall.FlushFileBuffers(c.h)
+			procDisconnectNamedPipe.Call(uintptr(c.h))
+		}
+		err = syscall.CloseHandle(c.h)
+	})
+	return err
+}
+
+func (c *pipeConn) LocalAddr() net.Addr                { return pipeAddr(c.path) }
+func (c *pipeConn) RemoteAddr() net.Addr               { return pipeAddr(c.path) }
+func (c *pipeConn) SetDeadline(t time.Time) error      { return errPipeDeadline }
+func (c *pipeConn) SetReadDeadline(t time.Time) error  { return errPipeDeadline }
+func (c *pipeConn) SetWriteDeadline(t time.Time) error { return errPipeDeadline }
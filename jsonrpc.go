@@ -0,0 +1,709 @@
+package golsptoolkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrAlreadyReplied is returned by a Replier called more than once for the
+// same request.
+var ErrAlreadyReplied = errors.New("golsptoolkit: request already answered")
+
+// wireMessage is the union of all message kinds, used to decode incoming
+// traffic before it is known whether it is a request, a notification or a
+// response.
+type wireMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      *ID             `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *ResponseError  `json:"error,omitempty"`
+
+	// hasID records whether the message had an id member, even a null one.
+	hasID bool
+}
+
+// answersRejection reports whether a message rejected by decodeMessage is
+// answered: anything but a notification, which is recognized by a method
+// without an id member, is.
+func (m *wireMessage) answersRejection() bool {
+	return m.Method == "" || m.hasID
+}
+
+// JSONRPCConn is a JSON-RPC 2.0 peer running on top of a Conn. It sends
+// requests and notifications, correlates responses with the calls that
+// are waiting for them, and dispatches incoming requests and notifications
+// to a Handler.
+//
+// By default notifications are handled one at a time, in the order they
+// arrive, on a single dispatch goroutine. Each request is handled on its own
+// goroutine, so a handler may itself issue calls to the peer. A Scheduler
+// can replace this policy.
+//
+// Messages are read independently of their handling, so control messages
+// are never stuck behind queued work: responses and $/cancelRequest take
+// effect as soon as they arrive, and exit jumps ahead of the messages still
+// waiting to be dispatched, which are discarded.
+//
+// A panic in a handler is recovered and logged, and the request is answered
+// with an InternalError.
+//
+// Every request is answered exactly once. A handler that returns without
+// calling reply, and without calling Detach, is reported in the log and the
+// request is answered with an InternalError; replying a second time fails
+// with ErrAlreadyReplied.
+//
+// The connection follows the shutdown sequence of the protocol. Once a
+// shutdown request arrives, further requests are answered with
+// InvalidRequest and further notifications are dropped, right away; the
+// shutdown request itself keeps its place in line and reaches the handler
+// only after the requests received before it have been answered, subject to
+// ShutdownTimeout. The exit notification is handled and then ends Run.
+//
+// Cancellation is wired in both directions: a $/cancelRequest from the peer
+// cancels the context of the matching handler, and a Call whose context is
+// canceled sends $/cancelRequest to the peer.
+//
+// A $/setTrace notification from the peer sets the trace level that
+// LogTrace follows.
+type JSONRPCConn struct {
+	// ErrorLog, if non-nil, receives errors that cannot be reported to the
+	// peer. If nil, the log package's standard logger is used.
+	ErrorLog *log.Logger
+
+	// ShutdownTimeout bounds how long a shutdown request waits for the
+	// requests still being handled to be answered. When it expires their
+	// contexts are canceled and shutdown proceeds. Zero means no limit.
+	ShutdownTimeout time.Duration
+
+	// CallTimeout bounds how long Call waits for a response when its
+	// context has no deadline of its own. Zero means no limit.
+	CallTimeout time.Duration
+
+	// IDGenerator, if non-nil, produces the ids of the requests sent by
+	// Call. If nil, ids are the integers 1, 2, 3, and so on.
+	IDGenerator IDGenerator
+
+	// Batch selects how incoming JSON-RPC batches are treated. The zero
+	// value rejects them.
+	Batch BatchPolicy
+
+	// Scheduler, if non-nil, decides when and on which goroutine incoming
+	// requests and notifications are handled. If nil, notifications are
+	// handled in order on the dispatch goroutine and each request on its
+	// own goroutine.
+	Scheduler Scheduler
+
+	conn    Conn
+	handler Handler
+
+	ids IntIDGenerator
+
+	mu           sync.Mutex
+	pending      map[ID]chan *wireMessage
+	inflight     map[ID]*inflightRequest
+	shuttingDown bool
+	trace        TraceValue
+	err          error
+
+	queue  dispatchQueue
+	exited chan struct{}
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewJSONRPCConn returns a JSONRPCConn exchanging messages over conn and
+// dispatching incoming requests and notifications to handler. A nil handler
+// answers every request with MethodNotFound.
+//
+// No message is read until Run is called.
+func NewJSONRPCConn(conn Conn, handler Handler) *JSONRPCConn {
+	return &JSONRPCConn{
+		conn:     conn,
+		handler:  handler,
+		pending:  make(map[ID]chan *wireMessage),
+		inflight: make(map[ID]*inflightRequest),
+		queue:    dispatchQueue{ready: make(chan struct{}, 1)},
+		exited:   make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Run reads and dispatches incoming messages until the connection is closed,
+// the peer hangs up, or ctx is canceled. It returns nil if the peer closed
+// the connection cleanly. The connection is closed when Run returns, and
+// pending calls fail.
+func (c *JSONRPCConn) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	dispatched := make(chan struct{})
+	go func() {
+		defer close(dispatched)
+		c.dispatchLoop(ctx)
+	}()
+
+	err := c.readLoop(ctx)
+	if errors.Is(err, io.EOF) {
+		err = nil
+	}
+	c.shutdown(err)
+	cancel()
+	<-dispatched
+	return err
+}
+
+func (c *JSONRPCConn) readLoop(ctx context.Context) error {
+	for {
+		body, err := c.conn.ReadMessage(ctx)
+		if err != nil {
+			if resp := FramingErrorResponse(err); resp != nil {
+				c.logf("rejected message: %v", err)
+				c.write(ctx, resp)
+				continue
+			}
+			return err
+		}
+
+		if isJSONArray(body) {
+			err = c.readBatch(ctx, body)
+		} else {
+			err = c.route(ctx, body, nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// route decodes a single message and acts on it. Answers to requests go to
+// b if it is non-nil. It returns io.EOF once the exit notification has been
+// handled.
+func (c *JSONRPCConn) route(ctx context.Context, body []byte, b *batchReply) error {
+	msg, rerr := decodeMessage(body)
+	if rerr != nil {
+		c.logf("rejected message: %s", rerr.Message)
+		if msg.answersRejection() {
+			c.respond(ctx, b, errorResponse(msg.ID, rerr))
+		}
+		releaseWireMessage(msg)
+		return nil
+	}
+	if msg.Method == "" && msg.ID != nil {
+		// The call waiting for the response releases it.
+		c.deliver(msg)
+		return nil
+	}
+	defer releaseWireMessage(msg)
+
+	switch {
+	case msg.Method == MethodCancelRequest:
+		c.cancelInflight(msg.Params)
+	case msg.Method == MethodSetTrace && msg.ID == nil:
+		c.setPeerTrace(msg.Params)
+	case msg.Method == MethodExit && msg.ID == nil:
+		c.queue.pushFront(&incoming{req: &Request{Method: msg.Method, Params: msg.Params}})
+		select {
+		case <-c.exited:
+			// The client ended the session as the protocol intends.
+			return io.EOF
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	case msg.Method != "":
+		if in := c.accept(ctx, &Request{ID: msg.ID, Method: msg.Method, Params: msg.Params}, b); in != nil {
+			c.queue.push(in)
+		}
+	case msg.Error != nil:
+		c.logf("peer reported error: %s", msg.Error.Message)
+	default:
+		c.logf("ignoring message that is neither request nor response")
+	}
+	return nil
+}
+
+// inflightRequest is an incoming request that has not been answered yet.
+type inflightRequest struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// incoming is a message waiting to be dispatched.
+type incoming struct {
+	req *Request
+
+	// The remaining fields are set for requests only.
+	ctx      context.Context
+	reply    Replier
+	replied  func() bool
+	state    *requestState
+	draining []*inflightRequest
+}
+
+// dispatchQueue holds the messages waiting to be dispatched.
+type dispatchQueue struct {
+	mu    sync.Mutex
+	items []*incoming
+	ready chan struct{}
+}
+
+func (q *dispatchQueue) push(in *incoming) {
+	q.mu.Lock()
+	q.items = append(q.items, in)
+	q.mu.Unlock()
+	signal(q.ready)
+}
+
+// pushFront queues in ahead of every message already waiting.
+func (q *dispatchQueue) pushFront(in *incoming) {
+	q.mu.Lock()
+	q.items = append([]*incoming{in}, q.items...)
+	q.mu.Unlock()
+	signal(q.ready)
+}
+
+// pop removes the first message, waiting for one if the queue is empty. It
+// reports false once ctx is done.
+func (q *dispatchQueue) pop(ctx context.Context) (*incoming, bool) {
+	for {
+		q.mu.Lock()
+		if len(q.items) > 0 {
+			in := q.items[0]
+			q.items[0] = nil
+			q.items = q.items[1:]
+			q.mu.Unlock()
+			return in, true
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-q.ready:
+		case <-ctx.Done():
+			return nil, false
+		}
+	}
+}
+
+// accept prepares req for dispatching as soon as it is read. It returns nil
+// if req needs no dispatching, because it was answered or dropped at once.
+func (c *JSONRPCConn) accept(ctx context.Context, req *Request, b *batchReply) *incoming {
+	c.mu.Lock()
+	if !req.IsNotification() {
+		// Answering a reused id would also answer the request that holds it.
+		if _, ok := c.inflight[*req.ID]; ok {
+			c.mu.Unlock()
+			c.respond(ctx, b, errorResponse(req.ID, Errorf(InvalidRequest, "request id %s is already in use", req.ID)))
+			return nil
+		}
+	}
+	down := c.shuttingDown
+	if req.Method == MethodShutdown && !req.IsNotification() {
+		c.shuttingDown = true
+	}
+	c.mu.Unlock()
+	if down {
+		if !req.IsNotification() {
+			c.respond(ctx, b, errorResponse(req.ID, NewError(InvalidRequest, "server is shutting down")))
+		}
+		return nil
+	}
+
+	if req.IsNotification() {
+		if c.handler == nil {
+			return nil
+		}
+		return &incoming{req: req}
+	}
+
+	reply, replied := c.replier(req, b)
+	if c.handler == nil {
+		reply(ctx, nil, &ResponseError{Code: MethodNotFound, Message: "method not found: " + req.Method})
+		return nil
+	}
+
+	hctx, cancel := context.WithCancel(ctx)
+	c.mu.Lock()
+	var draining []*inflightRequest
+	if req.Method == MethodShutdown {
+		for _, r := range c.inflight {
+			draining = append(draining, r)
+		}
+	}
+	c.inflight[*req.ID] = &inflightRequest{cancel: cancel, done: make(chan struct{})}
+	c.mu.Unlock()
+
+	st := &requestState{conn: c}
+	return &incoming{
+		req:      req,
+		ctx:      context.WithValue(hctx, requestStateKey{}, st),
+		reply:    reply,
+		replied:  replied,
+		state:    st,
+		draining: draining,
+	}
+}
+
+// dispatchLoop hands the queued messages to the handler, in order, until
+// ctx is done.
+func (c *JSONRPCConn) dispatchLoop(ctx context.Context) {
+	for {
+		in, ok := c.queue.pop(ctx)
+		if !ok {
+			return
+		}
+		c.dispatch(ctx, in)
+	}
+}
+
+// dispatch hands a queued message to the handler.
+func (c *JSONRPCConn) dispatch(ctx context.Context, in *incoming) {
+	req := in.req
+	if req.Method == MethodExit && req.IsNotification() {
+		if c.handler != nil {
+			c.handleNotification(ctx, req)
+		}
+		close(c.exited)
+		return
+	}
+	if req.IsNotification() {
+		c.schedule(req, func() {
+			c.handleNotification(ctx, req)
+		})
+		return
+	}
+
+	if err := in.ctx.Err(); err != nil {
+		// Canceled while waiting to be dispatched.
+		in.reply(ctx, nil, NewError(RequestCancelled, err.Error()))
+		return
+	}
+	c.schedule(req, func() {
+		defer c.recoverPanic(ctx, req, in.reply, in.replied)
+		if in.draining != nil {
+			c.drain(in.ctx, in.draining)
+		}
+		c.handler.Handle(in.ctx, in.reply, req)
+		if !in.replied() && !in.state.detached.Load() {
+			c.logf("handler for %s (id %s) returned without replying", req.Method, req.ID)
+			in.reply(ctx, nil, &ResponseError{Code: InternalError, Message: "request dropped by handler: " + req.Method})
+		}
+	})
+}
+
+func (c *JSONRPCConn) handleNotification(ctx context.Context, req *Request) {
+	defer c.recoverPanic(ctx, req, nil, nil)
+	c.handler.Handle(ctx, c.notificationReplier(req), req)
+}
+
+// recoverPanic, deferred around a handler, keeps a panic in the handler from
+// taking the process down. The panic is logged with its stack trace and, if
+// the handler has not answered yet, the request is answered with an
+// InternalError.
+func (c *JSONRPCConn) recoverPanic(ctx context.Context, req *Request, reply Replier, replied func() bool) {
+	p := recover()
+	if p == nil {
+		return
+	}
+	c.logf("panic handling %s: %v\n%s", req.Method, p, debug.Stack())
+	if reply != nil && !replied() {
+		reply(ctx, nil, Errorf(InternalError, "panic handling %s: %v", req.Method, p))
+	}
+}
+
+// schedule runs the handling of req through the Scheduler, or by default
+// inline for notifications and on a new goroutine for requests.
+func (c *JSONRPCConn) schedule(req *Request, run func()) {
+	switch {
+	case c.Scheduler != nil:
+		c.Scheduler.Schedule(req, run)
+	case req.IsNotification():
+		run()
+	default:
+		go run()
+	}
+}
+
+// drain waits for reqs to be answered, for at most ShutdownTimeout, and then
+// cancels those that are left.
+func (c *JSONRPCConn) drain(ctx context.Context, reqs []*inflightRequest) {
+	var expired <-chan time.Time
+	if c.ShutdownTimeout > 0 {
+		t := time.NewTimer(c.ShutdownTimeout)
+		defer t.Stop()
+		expired = t.C
+	}
+	for i, r := range reqs {
+		select {
+		case <-r.done:
+		case <-expired:
+			for _, r := range reqs[i:] {
+				r.cancel()
+			}
+			c.logf("shutdown: canceled requests unanswered after %v", c.ShutdownTimeout)
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// cancelInflight cancels the context of the request named by the params of
+// a $/cancelRequest notification, if it has not been answered yet.
+func (c *JSONRPCConn) cancelInflight(raw json.RawMessage) {
+	params, err := decodeParams[CancelParams](raw)
+	if err != nil {
+		c.logf("invalid %s: %v", MethodCancelRequest, err)
+		return
+	}
+
+	c.mu.Lock()
+	r, ok := c.inflight[params.ID]
+	c.mu.Unlock()
+	if ok {
+		r.cancel()
+	}
+}
+
+// replier returns the Replier answering req, and a function reporting
+// whether it has been called. Replying ends the request: its context is
+// canceled and later $/cancelRequest notifications for it are ignored. The
+// answer goes to b if req is part of a batch.
+func (c *JSONRPCConn) replier(req *Request, b *batchReply) (reply Replier, replied func() bool) {
+	var once atomic.Bool
+	reply = func(ctx context.Context, result any, err error) error {
+		if !once.CompareAndSwap(false, true) {
+			c.logf("handler for %s (id %s) replied more than once", req.Method, req.ID)
+			return ErrAlreadyReplied
+		}
+
+		c.mu.Lock()
+		r, ok := c.inflight[*req.ID]
+		delete(c.inflight, *req.ID)
+		c.mu.Unlock()
+		if ok {
+			defer close(r.done)
+			defer r.cancel()
+		}
+
+		// Handlers commonly reply with their own context, which may have
+		// been canceled by the time they answer; the answer is still due.
+		ctx = context.WithoutCancel(ctx)
+
+		msg := AcquireResponseMessage()
+		defer ReleaseResponseMessage(msg)
+		msg.ID = req.ID
+		if err != nil {
+			msg.Error = toResponseError(err)
+		} else {
+			msg.Result = result
+		}
+		return c.respond(ctx, b, msg)
+	}
+	return reply, once.Load
+}
+
+// notificationReplier returns the Replier passed along with a notification.
+// Nothing is sent, but errors are logged since they cannot reach the peer.
+func (c *JSONRPCConn) notificationReplier(req *Request) Replier {
+	return func(_ context.Context, _ any, err error) error {
+		if err != nil {
+			c.logf("notification %s failed: %v", req.Method, err)
+		}
+		return nil
+	}
+}
+
+// deliver routes a response to the call waiting for it.
+func (c *JSONRPCConn) deliver(msg *wireMessage) {
+	c.mu.Lock()
+	ch, ok := c.pending[*msg.ID]
+	delete(c.pending, *msg.ID)
+	c.mu.Unlock()
+
+	if !ok {
+		c.logf("ignoring response to unknown request %s", msg.ID)
+		releaseWireMessage(msg)
+		return
+	}
+	ch <- msg
+}
+
+// Call sends a request and waits for its response. On success the result
+// is decoded into result, which may be nil to discard it. If the peer
+// answers with an error, Call returns it as a *ResponseError. If ctx is done
+// first, the peer is sent a $/cancelRequest for the call and Call returns the
+// context's error.
+//
+// Call works in both directions: a server uses it for requests to the
+// client such as workspace/configuration. Run must be running for the
+// response to be received.
+func (c *JSONRPCConn) Call(ctx context.Context, method string, params, result any) error {
+	if _, ok := ctx.Deadline(); !ok && c.CallTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.CallTimeout)
+		defer cancel()
+	}
+
+	id := c.nextID()
+	ch := make(chan *wireMessage, 1)
+
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return c.err
+	}
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	forget := func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}
+
+	msg := AcquireRequestMessage()
+	msg.ID, msg.Method, msg.Params = id, method, params
+	err := c.write(ctx, msg)
+	ReleaseRequestMessage(msg)
+	if err != nil {
+		forget()
+		return err
+	}
+
+	select {
+	case msg := <-ch:
+		if msg == nil {
+			return c.closedErr()
+		}
+		defer releaseWireMessage(msg)
+		if msg.Error != nil {
+			return msg.Error
+		}
+		if result == nil {
+			return nil
+		}
+		return json.Unmarshal(msg.Result, result)
+	case <-ctx.Done():
+		forget()
+		c.Notify(context.WithoutCancel(ctx), MethodCancelRequest, &CancelParams{ID: id})
+		return ctx.Err()
+	}
+}
+
+func (c *JSONRPCConn) nextID() ID {
+	if c.IDGenerator != nil {
+		return c.IDGenerator.NextID()
+	}
+	return c.ids.NextID()
+}
+
+// Notify sends a notification.
+func (c *JSONRPCConn) Notify(ctx context.Context, method string, params any) error {
+	msg := AcquireNotificationMessage()
+	defer ReleaseNotificationMessage(msg)
+	msg.Method, msg.Params = method, params
+	return c.write(ctx, msg)
+}
+
+// Close closes the underlying connection, which makes Run return.
+func (c *JSONRPCConn) Close() error {
+	err := c.conn.Close()
+	c.shutdown(net.ErrClosed)
+	return err
+}
+
+// Done returns a channel that is closed once the connection has shut down.
+func (c *JSONRPCConn) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns the error that shut the connection down, or nil if it is still
+// running or the peer closed it cleanly.
+func (c *JSONRPCConn) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if errors.Is(c.err, io.EOF) {
+		return nil
+	}
+	return c.err
+}
+
+func (c *JSONRPCConn) closedErr() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// shutdown fails all pending calls and marks the connection done.
+func (c *JSONRPCConn) shutdown(err error) {
+	c.closeOnce.Do(func() {
+		c.conn.Close()
+
+		if err == nil {
+			err = io.EOF
+		}
+		c.mu.Lock()
+		c.err = err
+		pending := c.pending
+		c.pending = nil
+		c.mu.Unlock()
+
+		for _, ch := range pending {
+			close(ch)
+		}
+		close(c.done)
+	})
+}
+
+func (c *JSONRPCConn) write(ctx context.Context, msg any) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return c.conn.WriteMessage(ctx, body)
+}
+
+func (c *JSONRPCConn) logf(format string, args ...any) {
+	msg := fmt.Sprintf("golsptoolkit: "+format, args...)
+	if c.ErrorLog != nil {
+		c.ErrorLog.Print(msg)
+	} else {
+		log.Print(msg)
+	}
+}
+
+// errorResponse returns a response carrying rerr for the request id.
+func errorResponse(id *ID, rerr *ResponseError) *ResponseMessage {
+	return &ResponseMessage{
+		AbstractMessage: AbstractMessage{JSONRPC: JSONRPCVersion},
+		ID:              id,
+		Error:           rerr,
+	}
+}
+
+// toResponseError converts an error returned by a handler into the error
+// sent to the peer. A handler that gave up because its context was canceled
+// answers with RequestCancelled, and one whose deadline passed, as set by
+// TimeoutHandler, answers with RequestFailed.
+func toResponseError(err error) *ResponseError {
+	var rerr *ResponseError
+	if errors.As(err, &rerr) {
+		return rerr
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return &ResponseError{Code: RequestCancelled, Message: err.Error()}
+	case errors.Is(err, context.DeadlineExceeded):
+		return &ResponseError{Code: RequestFailed, Message: err.Error()}
+	}
+	return &ResponseError{Code: InternalError, Message: err.Error()}
+}
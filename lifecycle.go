@@ -0,0 +1,110 @@
+package golsptoolkit
+
+// Methods of the lifecycle messages.
+const (
+	MethodInitialize  = "initialize"
+	MethodInitialized = "initialized"
+	MethodShutdown    = "shutdown"
+	MethodExit        = "exit"
+)
+
+// InitializeParams are the parameters of the initialize request, the first
+// request a client sends to the server.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#initialize
+type InitializeParams struct {
+	WorkDoneProgressParams
+
+	// ProcessID is the process id of the parent process that started the
+	// server, or nil if it was not started by another process. The server
+	// should exit if that process is no longer alive.
+	ProcessID *Integer `json:"processId"`
+
+	// ClientInfo describes the client.
+	ClientInfo *ClientInfo `json:"clientInfo,omitempty"`
+
+	// Locale is the locale the client is showing its user interface in,
+	// such as "en-US", as an IETF language tag.
+	Locale string `json:"locale,omitempty"`
+
+	// RootPath is the root path of the workspace. It is null if no folder
+	// is open.
+	//
+	// Deprecated: Use WorkspaceFolders instead.
+	RootPath Optional[string] `json:"rootPath,omitzero"`
+
+	// RootURI is the root URI of the workspace, or nil if no folder is
+	// open. WorkspaceFolders takes precedence when it is set.
+	//
+	// Deprecated: Use WorkspaceFolders instead.
+	RootURI *DocumentURI `json:"rootUri"`
+
+	// InitializationOptions are user provided options for the server.
+	InitializationOptions LSPAny `json:"initializationOptions,omitempty"`
+
+	// Capabilities are the capabilities of the client.
+	Capabilities ClientCapabilities `json:"capabilities"`
+
+	// Trace is the initial trace setting. If omitted, tracing is off.
+	Trace TraceValue `json:"trace,omitempty"`
+
+	// WorkspaceFolders are the workspace folders open when the server
+	// starts. It is absent if the client does not support workspace
+	// folders, and null if no folder is open.
+	WorkspaceFolders Optional[[]WorkspaceFolder] `json:"workspaceFolders,omitzero"`
+}
+
+// ClientInfo describes the client in InitializeParams.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#initialize
+type ClientInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// InitializeResult is the result of the initialize request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#initialize
+type InitializeResult struct {
+	// Capabilities are the capabilities the server provides.
+	Capabilities ServerCapabilities `json:"capabilities"`
+
+	// ServerInfo describes the server.
+	ServerInfo *ServerInfo `json:"serverInfo,omitempty"`
+}
+
+// ServerInfo describes the server in InitializeResult.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#initialize
+type ServerInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// InitializeErrorCodes are the error codes specific to the initialize
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#initialize
+const (
+	// UnknownProtocolVersion signals that the server cannot handle the
+	// protocol version of the client.
+	//
+	// Deprecated: The protocol version is no longer sent; servers should
+	// not use this code.
+	UnknownProtocolVersion Integer = 1
+)
+
+// InitializeError is the data of the response error of a failed initialize
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#initialize
+type InitializeError struct {
+	// Retry asks the client to show the error message to the user and
+	// retry the initialize request if the user chooses to.
+	Retry bool `json:"retry"`
+}
+
+// InitializedParams are the parameters of the initialized notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#initialized
+type InitializedParams struct{}
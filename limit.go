@@ -0,0 +1,89 @@
+package golsptoolkit
+
+import (
+	"context"
+	"sync"
+)
+
+// LimitHandler is a Handler that bounds how many requests are handled at
+// once, so that an expensive request such as workspace/symbol cannot starve
+// latency-sensitive ones such as hover or completion.
+//
+// A request over a limit waits for a slot; if its context is canceled while
+// waiting it is answered with RequestCancelled without reaching Handler.
+// Notifications are passed through unrestricted, since holding them back
+// would stall the connection. A request counts against the limits until
+// Handler returns, even if it called Detach.
+type LimitHandler struct {
+	// Handler handles the requests.
+	Handler Handler
+
+	// MaxConcurrent limits the requests handled at once across all methods.
+	// Zero means no limit.
+	MaxConcurrent int
+
+	// MethodLimits limits the requests handled at once per method. Methods
+	// not listed are only subject to MaxConcurrent.
+	MethodLimits map[string]int
+
+	once    sync.Once
+	global  chan struct{}
+	methods map[string]chan struct{}
+}
+
+// Handle implements Handler.
+func (h *LimitHandler) Handle(ctx context.Context, reply Replier, req *Request) {
+	if req.IsNotification() {
+		h.Handler.Handle(ctx, reply, req)
+		return
+	}
+	h.once.Do(h.init)
+
+	// The method slot is taken first, so that a request queued behind
+	// others of its kind does not hold a global slot meanwhile.
+	method := h.methods[req.Method]
+	if !acquire(ctx, method) {
+		reply(ctx, nil, NewError(RequestCancelled, ctx.Err().Error()))
+		return
+	}
+	defer release(method)
+	if !acquire(ctx, h.global) {
+		reply(ctx, nil, NewError(RequestCancelled, ctx.Err().Error()))
+		return
+	}
+	defer release(h.global)
+
+	h.Handler.Handle(ctx, reply, req)
+}
+
+func (h *LimitHandler) init() {
+	if h.MaxConcurrent > 0 {
+		h.global = make(chan struct{}, h.MaxConcurrent)
+	}
+	h.methods = make(map[string]chan struct{}, len(h.MethodLimits))
+	for method, n := range h.MethodLimits {
+		if n > 0 {
+			h.methods[method] = make(chan struct{}, n)
+		}
+	}
+}
+
+// acquire takes a slot of sem, which may be nil for no limit. It reports
+// false if ctx is done first.
+func acquire(ctx context.Context, sem chan struct{}) bool {
+	if sem == nil {
+		return true
+	}
+	select {
+	case sem <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func release(sem chan struct{}) {
+	if sem != nil {
+		<-sem
+	}
+}
@@ -0,0 +1,53 @@
+package golsptoolkit
+
+// Methods of linked editing ranges.
+const (
+	MethodTextDocumentLinkedEditingRange = "textDocument/linkedEditingRange"
+)
+
+// LinkedEditingRangeOptions represents the linked editing range options a
+// server announces in ServerCapabilities.LinkedEditingRangeProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#linkedEditingRangeOptions
+type LinkedEditingRangeOptions struct {
+	WorkDoneProgressOptions
+}
+
+// LinkedEditingRangeRegistrationOptions are the options of a dynamic or
+// static registration of linked editing ranges.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#linkedEditingRangeRegistrationOptions
+type LinkedEditingRangeRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	LinkedEditingRangeOptions
+	StaticRegistrationOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (LinkedEditingRangeRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentLinkedEditingRange
+}
+
+// LinkedEditingRangeParams are the parameters of the
+// textDocument/linkedEditingRange request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#linkedEditingRangeParams
+type LinkedEditingRangeParams struct {
+	TextDocumentPositionParams
+	WorkDoneProgressParams
+}
+
+// LinkedEditingRanges is the result of the textDocument/linkedEditingRange
+// request: ranges that have the same content and are edited together, such
+// as the opening and closing tags of an HTML element.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#linkedEditingRanges
+type LinkedEditingRanges struct {
+	// Ranges are the ranges to edit together. They must have the same
+	// length and content, and must not overlap.
+	Ranges []Range `json:"ranges"`
+
+	// WordPattern is a regular expression describing valid contents of
+	// the ranges. If empty, the client's configured word pattern is used.
+	WordPattern string `json:"wordPattern,omitempty"`
+}
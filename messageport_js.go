@@ -0,0 +1,109 @@
+//go:build js && wasm
+
+package golsptoolkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"syscall/js"
+)
+
+// errMessagePortBody is returned by MessagePortConn.WriteMessage for a body
+// that is not valid JSON and thus cannot be posted as an object.
+var errMessagePortBody = errors.New("golsptoolkit: message body is not valid JSON")
+
+// MessagePortConn exchanges LSP messages with JavaScript through postMessage,
+// so a language server compiled to WebAssembly can run entirely inside the
+// browser. The port may be a MessagePort, a Worker, or the global scope of a
+// Web Worker.
+//
+// Outgoing messages are posted as plain JavaScript objects, the format used
+// by the BrowserMessageReader and BrowserMessageWriter of
+// vscode-languageserver. Incoming messages may be objects, JSON strings or
+// Uint8Array values holding JSON.
+type MessagePortConn struct {
+	*inbox
+	port      js.Value
+	onMessage js.Func
+	closeOnce sync.Once
+}
+
+// NewMessagePortConn returns a MessagePortConn exchanging messages over port.
+func NewMessagePortConn(port js.Value) *MessagePortConn {
+	c := &MessagePortConn{inbox: newInbox(), port: port}
+	c.onMessage = js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) > 0 {
+			if body := messageData(args[0].Get("data")); body != nil {
+				c.push(body)
+			}
+		}
+		return nil
+	})
+	port.Call("addEventListener", "message", c.onMessage)
+
+	// MessagePort only dispatches to listeners added with addEventListener
+	// after start is called.
+	if start := port.Get("start"); start.Type() == js.TypeFunction {
+		port.Call("start")
+	}
+	return c
+}
+
+// NewWorkerConn returns a MessagePortConn on the global scope of the Web
+// Worker the program runs in.
+func NewWorkerConn() *MessagePortConn {
+	return NewMessagePortConn(js.Global())
+}
+
+func messageData(data js.Value) []byte {
+	switch data.Type() {
+	case js.TypeString:
+		return []byte(data.String())
+	case js.TypeObject:
+		if data.InstanceOf(js.Global().Get("Uint8Array")) {
+			body := make([]byte, data.Length())
+			js.CopyBytesToGo(body, data)
+			return body
+		}
+		return []byte(js.Global().Get("JSON").Call("stringify", data).String())
+	}
+	return nil
+}
+
+// WriteMessage posts body to the port as a JavaScript object.
+func (c *MessagePortConn) WriteMessage(ctx context.Context, body []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := c.closed(); err != nil {
+		return err
+	}
+	// JSON.parse throws on invalid input, which syscall/js turns into a
+	// panic.
+	if !json.Valid(body) {
+		return errMessagePortBody
+	}
+	msg := js.Global().Get("JSON").Call("parse", string(body))
+	c.port.Call("postMessage", msg)
+	return nil
+}
+
+// Close stops listening on the port and closes it if it is a MessagePort.
+func (c *MessagePortConn) Close() error {
+	c.closeOnce.Do(func() {
+		c.port.Call("removeEventListener", "message", c.onMessage)
+		c.onMessage.Release()
+		c.close(io.EOF)
+		// Calling close on a worker's global scope would terminate the
+		// worker itself.
+		if !c.port.Equal(js.Global()) && c.port.Get("close").Type() == js.TypeFunction {
+			c.port.Call("close")
+		}
+	})
+	return nil
+}
+
+var _ Conn = (*MessagePortConn)(nil)
@@ -0,0 +1,97 @@
+package golsptoolkit
+
+// Methods of monikers.
+const (
+	MethodTextDocumentMoniker = "textDocument/moniker"
+)
+
+// MonikerOptions represents the moniker options a server announces in
+// ServerCapabilities.MonikerProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#monikerOptions
+type MonikerOptions struct {
+	WorkDoneProgressOptions
+}
+
+// MonikerRegistrationOptions are the options of a dynamic registration of
+// monikers.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#monikerRegistrationOptions
+type MonikerRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	MonikerOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (MonikerRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentMoniker
+}
+
+// MonikerParams are the parameters of the textDocument/moniker request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#monikerParams
+type MonikerParams struct {
+	TextDocumentPositionParams
+	WorkDoneProgressParams
+	PartialResultParams
+}
+
+// UniquenessLevel represents the scope in which a moniker is unique.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#uniquenessLevel
+type UniquenessLevel = string
+
+const (
+	// UniquenessLevelDocument is unique within a document.
+	UniquenessLevelDocument UniquenessLevel = "document"
+
+	// UniquenessLevelProject is unique within a project that has been
+	// dumped.
+	UniquenessLevelProject UniquenessLevel = "project"
+
+	// UniquenessLevelGroup is unique within a group of projects, such as
+	// a Git repository.
+	UniquenessLevelGroup UniquenessLevel = "group"
+
+	// UniquenessLevelScheme is unique across the moniker scheme.
+	UniquenessLevelScheme UniquenessLevel = "scheme"
+
+	// UniquenessLevelGlobal is globally unique.
+	UniquenessLevelGlobal UniquenessLevel = "global"
+)
+
+// MonikerKind represents whether a moniker is imported into or exported
+// from a project.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#monikerKind
+type MonikerKind = string
+
+const (
+	// MonikerKindImport is a symbol imported into the project.
+	MonikerKindImport MonikerKind = "import"
+
+	// MonikerKindExport is a symbol exported from the project.
+	MonikerKindExport MonikerKind = "export"
+
+	// MonikerKindLocal is a symbol local to the project, such as a local
+	// variable, or one whose visibility is unknown.
+	MonikerKindLocal MonikerKind = "local"
+)
+
+// Moniker represents the identity of a symbol across documents and
+// projects, as used by index formats such as LSIF.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#moniker
+type Moniker struct {
+	// Scheme is the scheme of the moniker, such as "tsc" or "gomod".
+	Scheme string `json:"scheme"`
+
+	// Identifier is the identifier of the moniker, opaque to the client.
+	Identifier string `json:"identifier"`
+
+	// Unique is the scope in which the moniker is unique.
+	Unique UniquenessLevel `json:"unique"`
+
+	// Kind is the kind of the moniker, if known.
+	Kind MonikerKind `json:"kind,omitempty"`
+}
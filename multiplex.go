@@ -0,0 +1,219 @@
+package golsptoolkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"sync"
+)
+
+// ErrSessionExists is returned by SessionMux.Open for an id already in use.
+var ErrSessionExists = errors.New("golsptoolkit: session already open")
+
+// acceptBacklog bounds the sessions started by the peer that wait for
+// SessionMux.Accept.
+const acceptBacklog = 16
+
+// muxFrame is the envelope in which SessionMux tags every message with the
+// session it belongs to. A frame with Close set ends the session.
+type muxFrame struct {
+	Session string          `json:"session"`
+	Message json.RawMessage `json:"message,omitempty"`
+	Close   bool            `json:"close,omitempty"`
+}
+
+// SessionMux carries several independent LSP sessions over a single Conn,
+// for proxies and remote-development setups where one pipe serves multiple
+// workspaces. Each message travels wrapped in an envelope naming its
+// session:
+//
+//	{"session":"<id>","message":<message>}
+//
+// Both ends of the underlying Conn must use a SessionMux.
+//
+// Up to 16 sessions started by the peer wait for Accept; beyond that, new
+// sessions are refused by closing them. Frames for a session closed on this
+// side are dropped until the peer closes it too.
+type SessionMux struct {
+	conn Conn
+
+	mu       sync.Mutex
+	sessions map[string]*muxSession
+	err      error
+
+	// closed holds the ids of sessions ended on this side, whose frames
+	// still in flight are dropped until the peer's close frame arrives.
+	closed map[string]bool
+
+	accept chan *muxSession
+	done   chan struct{}
+}
+
+// NewSessionMux starts multiplexing sessions over conn. It takes ownership
+// of conn, which is closed by Close.
+func NewSessionMux(conn Conn) *SessionMux {
+	m := &SessionMux{
+		conn:     conn,
+		sessions: make(map[string]*muxSession),
+		closed:   make(map[string]bool),
+		accept:   make(chan *muxSession, acceptBacklog),
+		done:     make(chan struct{}),
+	}
+	go m.readLoop()
+	return m
+}
+
+// Open starts a new session with the given id. The peer sees it through
+// Accept once the first message arrives.
+func (m *SessionMux) Open(id string) (Conn, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return nil, m.err
+	}
+	if _, ok := m.sessions[id]; ok {
+		return nil, ErrSessionExists
+	}
+	s := newMuxSession(m, id)
+	m.sessions[id] = s
+	delete(m.closed, id)
+	return s, nil
+}
+
+// Accept waits for the peer to start a new session and returns it with its
+// id.
+func (m *SessionMux) Accept(ctx context.Context) (string, Conn, error) {
+	select {
+	case s := <-m.accept:
+		return s.id, s, nil
+	case <-m.done:
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return "", nil, m.err
+	case <-ctx.Done():
+		return "", nil, ctx.Err()
+	}
+}
+
+// Close ends every session and closes the underlying connection.
+func (m *SessionMux) Close() error {
+	err := m.conn.Close()
+	m.shutdown(net.ErrClosed)
+	return err
+}
+
+func (m *SessionMux) readLoop() {
+	for {
+		body, err := m.conn.ReadMessage(context.Background())
+		if err != nil {
+			m.shutdown(err)
+			return
+		}
+
+		var f muxFrame
+		if err := json.Unmarshal(body, &f); err != nil {
+			continue
+		}
+
+		m.mu.Lock()
+		s, ok := m.sessions[f.Session]
+		switch {
+		case f.Close:
+			if ok {
+				delete(m.sessions, f.Session)
+				s.close(io.EOF)
+			}
+			delete(m.closed, f.Session)
+			s = nil
+		case ok:
+		case m.closed[f.Session]:
+			// A late frame for a session closed on this side.
+		default:
+			s = m.start(f.Session)
+		}
+		m.mu.Unlock()
+
+		if s != nil {
+			s.push(f.Message)
+		}
+	}
+}
+
+// start hands a session started by the peer to Accept, or refuses it if
+// the backlog is full. It returns nil for a refused session. m.mu must be
+// held.
+func (m *SessionMux) start(id string) *muxSession {
+	s := newMuxSession(m, id)
+	select {
+	case m.accept <- s:
+		m.sessions[id] = s
+		return s
+	default:
+		m.closed[id] = true
+		// Writing must not stall the read loop, and thereby the other
+		// sessions.
+		go m.send(context.Background(), muxFrame{Session: id, Close: true})
+		return nil
+	}
+}
+
+func (m *SessionMux) shutdown(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return
+	}
+	m.err = err
+	for id, s := range m.sessions {
+		s.close(err)
+		delete(m.sessions, id)
+	}
+	close(m.done)
+}
+
+func (m *SessionMux) send(ctx context.Context, f muxFrame) error {
+	body, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	return m.conn.WriteMessage(ctx, body)
+}
+
+// muxSession is one session of a SessionMux.
+type muxSession struct {
+	*inbox
+	mux *SessionMux
+	id  string
+
+	closeOnce sync.Once
+}
+
+func newMuxSession(m *SessionMux, id string) *muxSession {
+	return &muxSession{inbox: newInbox(), mux: m, id: id}
+}
+
+func (s *muxSession) WriteMessage(ctx context.Context, body []byte) error {
+	if err := s.closed(); err != nil {
+		return err
+	}
+	return s.mux.send(ctx, muxFrame{Session: s.id, Message: body})
+}
+
+// Close ends the session on both sides without affecting other sessions.
+func (s *muxSession) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		s.mux.mu.Lock()
+		if s.mux.sessions[s.id] == s {
+			delete(s.mux.sessions, s.id)
+			s.mux.closed[s.id] = true
+		}
+		s.mux.mu.Unlock()
+
+		s.close(net.ErrClosed)
+		err = s.mux.send(context.Background(), muxFrame{Session: s.id, Close: true})
+	})
+	return err
+}
@@ -0,0 +1,89 @@
+package golsptoolkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestSessionMuxWithoutAccept(t *testing.T) {
+	client, server := Pipe()
+	a, b := NewSessionMux(client), NewSessionMux(server)
+	defer a.Close()
+	defer b.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ax, err := a.Open("x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bx, err := b.Open("x")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Nobody on b calls Accept, so sessions beyond the backlog are
+	// refused.
+	var refused Conn
+	for i := range acceptBacklog + 1 {
+		s, err := a.Open(fmt.Sprint("s", i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.WriteMessage(ctx, []byte(`{}`)); err != nil {
+			t.Fatal(err)
+		}
+		refused = s
+	}
+
+	if err := ax.WriteMessage(ctx, []byte(`{"n":1}`)); err != nil {
+		t.Fatal(err)
+	}
+	body, err := bx.ReadMessage(ctx)
+	if err != nil || string(body) != `{"n":1}` {
+		t.Fatalf("ReadMessage = %s, %v, want {\"n\":1}", body, err)
+	}
+
+	if _, err := refused.ReadMessage(ctx); !errors.Is(err, io.EOF) {
+		t.Fatalf("ReadMessage on refused session = %v, want io.EOF", err)
+	}
+}
+
+func TestSessionMuxDropsFramesForClosedSession(t *testing.T) {
+	client, server := Pipe()
+	a, b := NewSessionMux(client), NewSessionMux(server)
+	defer a.Close()
+	defer b.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	as, err := a.Open("s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := as.WriteMessage(ctx, []byte(`{"n":1}`)); err != nil {
+		t.Fatal(err)
+	}
+	_, bs, err := b.Accept(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs.Close()
+
+	// A frame sent before a learns of the close must not start a new
+	// session on b.
+	if err := a.send(ctx, muxFrame{Session: "s", Message: []byte(`{"n":2}`)}); err != nil {
+		t.Fatal(err)
+	}
+	actx, acancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer acancel()
+	if id, _, err := b.Accept(actx); err == nil {
+		t.Fatalf("Accept returned session %q for a late frame", id)
+	}
+}
@@ -0,0 +1,74 @@
+package golsptoolkit
+
+import (
+	"context"
+	"sync"
+)
+
+// Mux is a Handler that dispatches each incoming message to the handler
+// registered for its method, such as "initialize" or "textDocument/hover".
+//
+// Requests for unregistered methods are answered with MethodNotFound;
+// notifications for unregistered methods are dropped, as the specification
+// requires. This covers the implementation-dependent methods starting with
+// "$/", which a peer is free to ignore as notifications but must reject as
+// requests.
+type Mux struct {
+	// Unhandled, if non-nil, is called with every message for an
+	// unregistered method before it is answered or dropped, for example to
+	// log what a client expects that the server does not provide.
+	Unhandled func(ctx context.Context, req *Request)
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+// NewMux returns an empty Mux.
+func NewMux() *Mux {
+	return &Mux{handlers: make(map[string]Handler)}
+}
+
+// Register registers h for method. It panics if method is empty or already
+// has a handler.
+func (m *Mux) Register(method string, h Handler) {
+	if method == "" {
+		panic("golsptoolkit: empty method")
+	}
+	if h == nil {
+		panic("golsptoolkit: nil handler for " + method)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.handlers[method]; ok {
+		panic("golsptoolkit: multiple registrations for " + method)
+	}
+	m.handlers[method] = h
+}
+
+// RegisterFunc registers the handler function f for method.
+func (m *Mux) RegisterFunc(method string, f func(ctx context.Context, reply Replier, req *Request)) {
+	m.Register(method, HandlerFunc(f))
+}
+
+// Lookup returns the handler registered for method.
+func (m *Mux) Lookup(method string) (Handler, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	h, ok := m.handlers[method]
+	return h, ok
+}
+
+// Handle dispatches req to the handler registered for its method.
+func (m *Mux) Handle(ctx context.Context, reply Replier, req *Request) {
+	if h, ok := m.Lookup(req.Method); ok {
+		h.Handle(ctx, reply, req)
+		return
+	}
+	if m.Unhandled != nil {
+		m.Unhandled(ctx, req)
+	}
+	if !req.IsNotification() {
+		reply(ctx, nil, &ResponseError{Code: MethodNotFound, Message: "method not found: " + req.Method})
+	}
+}
@@ -0,0 +1,223 @@
+package golsptoolkit
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Methods of go-to navigation.
+const (
+	MethodTextDocumentDeclaration    = "textDocument/declaration"
+	MethodTextDocumentDefinition     = "textDocument/definition"
+	MethodTextDocumentTypeDefinition = "textDocument/typeDefinition"
+	MethodTextDocumentImplementation = "textDocument/implementation"
+)
+
+// DeclarationOptions represents the go to declaration options a server
+// announces in ServerCapabilities.DeclarationProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#declarationOptions
+type DeclarationOptions struct {
+	WorkDoneProgressOptions
+}
+
+// DeclarationRegistrationOptions are the options of a dynamic or static
+// registration of go to declaration.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#declarationRegistrationOptions
+type DeclarationRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	DeclarationOptions
+	StaticRegistrationOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (DeclarationRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentDeclaration
+}
+
+// DeclarationParams are the parameters of the textDocument/declaration
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#declarationParams
+type DeclarationParams struct {
+	TextDocumentPositionParams
+	WorkDoneProgressParams
+	PartialResultParams
+}
+
+// DefinitionOptions represents the go to definition options a server
+// announces in ServerCapabilities.DefinitionProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#definitionOptions
+type DefinitionOptions struct {
+	WorkDoneProgressOptions
+}
+
+// DefinitionRegistrationOptions are the options of a dynamic registration of
+// go to definition.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#definitionRegistrationOptions
+type DefinitionRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	DefinitionOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (DefinitionRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentDefinition
+}
+
+// DefinitionParams are the parameters of the textDocument/definition
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#definitionParams
+type DefinitionParams struct {
+	TextDocumentPositionParams
+	WorkDoneProgressParams
+	PartialResultParams
+}
+
+// TypeDefinitionOptions represents the go to type definition options a server
+// announces in ServerCapabilities.TypeDefinitionProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#typeDefinitionOptions
+type TypeDefinitionOptions struct {
+	WorkDoneProgressOptions
+}
+
+// TypeDefinitionRegistrationOptions are the options of a dynamic or static
+// registration of go to type definition.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#typeDefinitionRegistrationOptions
+type TypeDefinitionRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	TypeDefinitionOptions
+	StaticRegistrationOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (TypeDefinitionRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentTypeDefinition
+}
+
+// TypeDefinitionParams are the parameters of the textDocument/typeDefinition
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#typeDefinitionParams
+type TypeDefinitionParams struct {
+	TextDocumentPositionParams
+	WorkDoneProgressParams
+	PartialResultParams
+}
+
+// ImplementationOptions represents the go to implementation options a server
+// announces in ServerCapabilities.ImplementationProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#implementationOptions
+type ImplementationOptions struct {
+	WorkDoneProgressOptions
+}
+
+// ImplementationRegistrationOptions are the options of a dynamic or static
+// registration of go to implementation.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#implementationRegistrationOptions
+type ImplementationRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	ImplementationOptions
+	StaticRegistrationOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (ImplementationRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentImplementation
+}
+
+// ImplementationParams are the parameters of the textDocument/implementation
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#implementationParams
+type ImplementationParams struct {
+	TextDocumentPositionParams
+	WorkDoneProgressParams
+	PartialResultParams
+}
+
+// LocationResult is the result of the go-to navigation requests: either
+// locations or, for clients with linkSupport, location links. A nil
+// *LocationResult encodes as null, meaning no result.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_definition
+type LocationResult struct {
+	// Locations holds the result if Links is nil.
+	Locations []Location
+
+	// Links holds the result if it is made of location links.
+	Links []LocationLink
+}
+
+// NewLocationResult returns a result made of locs.
+func NewLocationResult(locs ...Location) *LocationResult {
+	return &LocationResult{Locations: locs}
+}
+
+// NewLocationLinkResult returns a result made of links if linkSupport is
+// true, as announced by the client for the request. Otherwise the links are
+// converted to locations with LocationLink.Location.
+func NewLocationLinkResult(linkSupport bool, links ...LocationLink) *LocationResult {
+	if linkSupport {
+		if links == nil {
+			links = []LocationLink{}
+		}
+		return &LocationResult{Links: links}
+	}
+	locs := make([]Location, len(links))
+	for i, l := range links {
+		locs[i] = l.Location()
+	}
+	return &LocationResult{Locations: locs}
+}
+
+// Location returns the target of l as a location, using its selection
+// range, for clients that do not support location links.
+func (l LocationLink) Location() Location {
+	return Location{URI: l.TargetURI, Range: l.TargetSelectionRange}
+}
+
+// MarshalJSON implements json.Marshaler. Locations are always encoded as an
+// array.
+func (r LocationResult) MarshalJSON() ([]byte, error) {
+	if r.Links != nil {
+		return json.Marshal(r.Links)
+	}
+	if r.Locations == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(r.Locations)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts a Location, an array
+// of Location, or an array of LocationLink.
+func (r *LocationResult) UnmarshalJSON(data []byte) error {
+	*r = LocationResult{}
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		r.Locations = make([]Location, 1)
+		return json.Unmarshal(data, &r.Locations[0])
+	}
+
+	var elems []json.RawMessage
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return err
+	}
+	if len(elems) > 0 {
+		var probe struct {
+			TargetURI *DocumentURI `json:"targetUri"`
+		}
+		if err := json.Unmarshal(elems[0], &probe); err == nil && probe.TargetURI != nil {
+			return json.Unmarshal(data, &r.Links)
+		}
+	}
+	return json.Unmarshal(data, &r.Locations)
+}
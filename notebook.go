@@ -0,0 +1,296 @@
+package golsptoolkit
+
+// Methods of notebook document synchronization.
+const (
+	MethodNotebookDocumentDidOpen   = "notebookDocument/didOpen"
+	MethodNotebookDocumentDidChange = "notebookDocument/didChange"
+	MethodNotebookDocumentDidSave   = "notebookDocument/didSave"
+	MethodNotebookDocumentDidClose  = "notebookDocument/didClose"
+
+	// MethodNotebookDocumentSync is the method under which notebook
+	// document synchronization is registered dynamically.
+	MethodNotebookDocumentSync = "notebookDocument/sync"
+)
+
+// NotebookDocument represents a notebook document.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#notebookDocument
+type NotebookDocument struct {
+	// URI is the URI of the notebook.
+	URI URI `json:"uri"`
+
+	// NotebookType is the type of the notebook, such as "jupyter-notebook".
+	NotebookType string `json:"notebookType"`
+
+	// Version increases after each change, including undo and redo.
+	Version Integer `json:"version"`
+
+	// Metadata is additional metadata stored with the notebook.
+	Metadata LSPObject `json:"metadata,omitempty"`
+
+	// Cells are the cells of the notebook.
+	Cells []NotebookCell `json:"cells"`
+}
+
+// NotebookCellKind represents the kind of a notebook cell.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#notebookCellKind
+type NotebookCellKind UInteger
+
+const (
+	// NotebookCellKindMarkup is a markup cell, formatted source shown as
+	// is.
+	NotebookCellKindMarkup NotebookCellKind = 1
+
+	// NotebookCellKindCode is a code cell, source that can be executed.
+	NotebookCellKindCode NotebookCellKind = 2
+)
+
+var notebookCellKindNames = enumNames{"NotebookCellKind", []string{
+	NotebookCellKindMarkup: "Markup",
+	NotebookCellKindCode:   "Code",
+}}
+
+// String returns the name of k, such as "Markup".
+func (k NotebookCellKind) String() string {
+	return notebookCellKindNames.name(int64(k))
+}
+
+// IsValid reports whether k is one of the defined notebook cell kinds.
+func (k NotebookCellKind) IsValid() bool {
+	return notebookCellKindNames.valid(int64(k))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Undefined values are rejected.
+func (k *NotebookCellKind) UnmarshalJSON(data []byte) error {
+	return decodeEnum(data, k, notebookCellKindNames)
+}
+
+// NotebookCell represents a cell of a notebook. Its content is synced as a
+// separate text document, identified by Document.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#notebookCell
+type NotebookCell struct {
+	// Kind is the kind of the cell.
+	Kind NotebookCellKind `json:"kind"`
+
+	// Document is the URI of the text document holding the content of
+	// the cell.
+	Document DocumentURI `json:"document"`
+
+	// Metadata is additional metadata stored with the cell.
+	Metadata LSPObject `json:"metadata,omitempty"`
+
+	// ExecutionSummary describes the last execution of the cell, if the
+	// client supports execution summaries.
+	ExecutionSummary *ExecutionSummary `json:"executionSummary,omitempty"`
+}
+
+// ExecutionSummary describes the execution of a notebook cell.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#executionSummary
+type ExecutionSummary struct {
+	// ExecutionOrder is the order of the execution among the executions
+	// of the notebook's cells.
+	ExecutionOrder UInteger `json:"executionOrder"`
+
+	// Success reports whether the execution succeeded, if known.
+	Success *bool `json:"success,omitempty"`
+}
+
+// NotebookDocumentFilter denotes a notebook document by its type, the
+// scheme of its URI, or a glob pattern applied to its path. At least one
+// property must be set.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#notebookDocumentFilter
+type NotebookDocumentFilter struct {
+	NotebookType string `json:"notebookType,omitempty"`
+	Scheme       string `json:"scheme,omitempty"`
+	Pattern      string `json:"pattern,omitempty"`
+}
+
+// NotebookCellTextDocumentFilter denotes the cell text documents of
+// notebooks.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#notebookCellTextDocumentFilter
+type NotebookCellTextDocumentFilter struct {
+	// Notebook selects the notebooks: a notebook type, matching all
+	// notebooks of that type, or a filter.
+	Notebook Or2[string, NotebookDocumentFilter] `json:"notebook"`
+
+	// Language is the language of the cells to select, such as "python".
+	// If empty, all cells are selected.
+	Language string `json:"language,omitempty"`
+}
+
+// NotebookDocumentSyncOptions represents the notebook sync options a server
+// announces in ServerCapabilities.NotebookDocumentSync.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#notebookDocumentSyncOptions
+type NotebookDocumentSyncOptions struct {
+	// NotebookSelector selects the notebooks to sync. A notebook is
+	// synced if any selector matches it.
+	NotebookSelector []NotebookSelector `json:"notebookSelector"`
+
+	// Save reports whether didSave notifications are sent to the server.
+	Save bool `json:"save,omitempty"`
+}
+
+// NotebookSelector selects notebooks and their cells for synchronization.
+// At least one of Notebook and Cells must be set.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#notebookDocumentSyncOptions
+type NotebookSelector struct {
+	// Notebook selects the notebooks: a notebook type or a filter. If
+	// zero, notebooks that contain cells matching Cells are selected.
+	Notebook Or2[string, NotebookDocumentFilter] `json:"notebook,omitzero"`
+
+	// Cells selects the cells to sync by language. If nil, all cells of
+	// the selected notebooks are synced.
+	Cells []NotebookCellLanguage `json:"cells,omitempty"`
+}
+
+// NotebookCellLanguage selects notebook cells by language.
+type NotebookCellLanguage struct {
+	Language string `json:"language"`
+}
+
+// NotebookDocumentSyncRegistrationOptions are the options of a dynamic or
+// static registration of notebook document synchronization.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#notebookDocumentSyncRegistrationOptions
+type NotebookDocumentSyncRegistrationOptions struct {
+	NotebookDocumentSyncOptions
+	StaticRegistrationOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (NotebookDocumentSyncRegistrationOptions) RegistrationMethod() string {
+	return MethodNotebookDocumentSync
+}
+
+// NotebookDocumentIdentifier identifies a notebook document by its URI.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#notebookDocumentIdentifier
+type NotebookDocumentIdentifier struct {
+	URI URI `json:"uri"`
+}
+
+// VersionedNotebookDocumentIdentifier identifies a specific version of a
+// notebook document.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#versionedNotebookDocumentIdentifier
+type VersionedNotebookDocumentIdentifier struct {
+	Version Integer `json:"version"`
+	URI     URI     `json:"uri"`
+}
+
+// DidOpenNotebookDocumentParams are the parameters of the
+// notebookDocument/didOpen notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#didOpenNotebookDocumentParams
+type DidOpenNotebookDocumentParams struct {
+	// NotebookDocument is the notebook that was opened.
+	NotebookDocument NotebookDocument `json:"notebookDocument"`
+
+	// CellTextDocuments are the text documents of the cells.
+	CellTextDocuments []TextDocumentItem `json:"cellTextDocuments"`
+}
+
+// DidChangeNotebookDocumentParams are the parameters of the
+// notebookDocument/didChange notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#didChangeNotebookDocumentParams
+type DidChangeNotebookDocumentParams struct {
+	// NotebookDocument is the notebook that changed, with its version
+	// after the change is applied.
+	NotebookDocument VersionedNotebookDocumentIdentifier `json:"notebookDocument"`
+
+	// Change is the change.
+	Change NotebookDocumentChangeEvent `json:"change"`
+}
+
+// NotebookDocumentChangeEvent represents a change to a notebook document.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#notebookDocumentChangeEvent
+type NotebookDocumentChangeEvent struct {
+	// Metadata is the new metadata of the notebook, if it changed.
+	Metadata LSPObject `json:"metadata,omitempty"`
+
+	// Cells are the changes to the cells, if any.
+	Cells *NotebookDocumentCellChanges `json:"cells,omitempty"`
+}
+
+// NotebookDocumentCellChanges represents the changes to the cells of a
+// notebook document.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#notebookDocumentChangeEvent
+type NotebookDocumentCellChanges struct {
+	// Structure is the change to the cell array, if cells were added or
+	// removed.
+	Structure *NotebookDocumentCellStructureChange `json:"structure,omitempty"`
+
+	// Data holds the cells whose properties, such as metadata or
+	// execution summary, changed.
+	Data []NotebookCell `json:"data,omitempty"`
+
+	// TextContent holds the changes to the text documents of cells.
+	TextContent []NotebookDocumentCellContentChanges `json:"textContent,omitempty"`
+}
+
+// NotebookDocumentCellStructureChange represents a change to the cell array
+// of a notebook document.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#notebookDocumentChangeEvent
+type NotebookDocumentCellStructureChange struct {
+	// Array is the change to the cell array.
+	Array NotebookCellArrayChange `json:"array"`
+
+	// DidOpen are the text documents of the added cells.
+	DidOpen []TextDocumentItem `json:"didOpen,omitempty"`
+
+	// DidClose are the text documents of the removed cells.
+	DidClose []TextDocumentIdentifier `json:"didClose,omitempty"`
+}
+
+// NotebookDocumentCellContentChanges represents the changes to the text
+// document of a cell.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#notebookDocumentChangeEvent
+type NotebookDocumentCellContentChanges struct {
+	Document VersionedTextDocumentIdentifier  `json:"document"`
+	Changes  []TextDocumentContentChangeEvent `json:"changes"`
+}
+
+// NotebookCellArrayChange represents a change to the cell array of a
+// notebook: DeleteCount cells are removed at Start, and Cells are inserted
+// there.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#notebookCellArrayChange
+type NotebookCellArrayChange struct {
+	Start       UInteger       `json:"start"`
+	DeleteCount UInteger       `json:"deleteCount"`
+	Cells       []NotebookCell `json:"cells,omitempty"`
+}
+
+// DidSaveNotebookDocumentParams are the parameters of the
+// notebookDocument/didSave notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#didSaveNotebookDocumentParams
+type DidSaveNotebookDocumentParams struct {
+	// NotebookDocument is the notebook that was saved.
+	NotebookDocument NotebookDocumentIdentifier `json:"notebookDocument"`
+}
+
+// DidCloseNotebookDocumentParams are the parameters of the
+// notebookDocument/didClose notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#didCloseNotebookDocumentParams
+type DidCloseNotebookDocumentParams struct {
+	// NotebookDocument is the notebook that was closed.
+	NotebookDocument NotebookDocumentIdentifier `json:"notebookDocument"`
+
+	// CellTextDocuments are the text documents of the cells that were
+	// closed along with the notebook.
+	CellTextDocuments []TextDocumentIdentifier `json:"cellTextDocuments"`
+}
@@ -0,0 +1,73 @@
+package golsptoolkit
+
+import "encoding/json"
+
+// Optional is a value of type T that may also be null or absent, for the
+// properties of the protocol where null and absent mean different things,
+// such as the workspaceFolders of InitializeParams. Struct fields of type
+// Optional are tagged omitzero so that an absent value is left out, while a
+// null value is sent as null.
+//
+// The zero Optional is absent.
+type Optional[T any] struct {
+	value T
+	state optionalState
+}
+
+// optionalState is the state of an Optional.
+type optionalState uint8
+
+const (
+	optionalAbsent optionalState = iota
+	optionalNull
+	optionalSet
+)
+
+// Some returns an Optional holding v.
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{value: v, state: optionalSet}
+}
+
+// Null returns a null Optional.
+func Null[T any]() Optional[T] {
+	return Optional[T]{state: optionalNull}
+}
+
+// Get returns the value of o, and whether o holds one.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.state == optionalSet
+}
+
+// IsNull reports whether o is null.
+func (o Optional[T]) IsNull() bool {
+	return o.state == optionalNull
+}
+
+// IsZero reports whether o is absent.
+func (o Optional[T]) IsZero() bool {
+	return o.state == optionalAbsent
+}
+
+// MarshalJSON implements json.Marshaler. An absent Optional that is not
+// left out with omitzero is sent as null.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if o.state != optionalSet {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It is only called for present
+// properties, so a property left out of the JSON stays absent.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		*o = Null[T]()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
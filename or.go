@@ -0,0 +1,184 @@
+package golsptoolkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Or2 holds a value of type A or of type B, for the union types of the
+// protocol such as string | MarkupContent. The zero Or2 holds no value and
+// encodes as null; struct fields of type Or2 are tagged omitzero to be left
+// out instead.
+//
+// A value is decoded into the first type that accepts it without unknown
+// object properties, or else into the first type that accepts it at all,
+// so alternatives that are both objects are told apart by their properties.
+type Or2[A, B any] struct {
+	a     A
+	b     B
+	which uint8
+}
+
+// NewOr2A returns an Or2 holding a. B is given explicitly, as in
+// NewOr2A[string, MarkupContent]("text").
+func NewOr2A[A, B any](a A) Or2[A, B] {
+	return Or2[A, B]{a: a, which: 1}
+}
+
+// NewOr2B returns an Or2 holding b. A is given explicitly, as in
+// NewOr2B[string](content).
+func NewOr2B[A, B any](b B) Or2[A, B] {
+	return Or2[A, B]{b: b, which: 2}
+}
+
+// A returns the value of type A, and whether o holds one.
+func (o Or2[A, B]) A() (A, bool) {
+	return o.a, o.which == 1
+}
+
+// B returns the value of type B, and whether o holds one.
+func (o Or2[A, B]) B() (B, bool) {
+	return o.b, o.which == 2
+}
+
+// Value returns the value held by o, or nil.
+func (o Or2[A, B]) Value() any {
+	switch o.which {
+	case 1:
+		return o.a
+	case 2:
+		return o.b
+	}
+	return nil
+}
+
+// IsZero reports whether o holds no value.
+func (o Or2[A, B]) IsZero() bool {
+	return o.which == 0
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o Or2[A, B]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.Value())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (o *Or2[A, B]) UnmarshalJSON(data []byte) error {
+	*o = Or2[A, B]{}
+	if isJSONNull(data) {
+		return nil
+	}
+	for _, strict := range []bool{true, false} {
+		if a, err := decodeAlternative[A](data, strict); err == nil {
+			*o = NewOr2A[A, B](a)
+			return nil
+		}
+		if b, err := decodeAlternative[B](data, strict); err == nil {
+			*o = NewOr2B[A](b)
+			return nil
+		}
+	}
+	return fmt.Errorf("golsptoolkit: %s is neither a %s nor a %s", data, typeName[A](), typeName[B]())
+}
+
+// Or3 holds a value of type A, B or C, like Or2 for unions of three types.
+type Or3[A, B, C any] struct {
+	a     A
+	b     B
+	c     C
+	which uint8
+}
+
+// NewOr3A returns an Or3 holding a.
+func NewOr3A[A, B, C any](a A) Or3[A, B, C] {
+	return Or3[A, B, C]{a: a, which: 1}
+}
+
+// NewOr3B returns an Or3 holding b.
+func NewOr3B[A, B, C any](b B) Or3[A, B, C] {
+	return Or3[A, B, C]{b: b, which: 2}
+}
+
+// NewOr3C returns an Or3 holding c.
+func NewOr3C[A, B, C any](c C) Or3[A, B, C] {
+	return Or3[A, B, C]{c: c, which: 3}
+}
+
+// A returns the value of type A, and whether o holds one.
+func (o Or3[A, B, C]) A() (A, bool) {
+	return o.a, o.which == 1
+}
+
+// B returns the value of type B, and whether o holds one.
+func (o Or3[A, B, C]) B() (B, bool) {
+	return o.b, o.which == 2
+}
+
+// C returns the value of type C, and whether o holds one.
+func (o Or3[A, B, C]) C() (C, bool) {
+	return o.c, o.which == 3
+}
+
+// Value returns the value held by o, or nil.
+func (o Or3[A, B, C]) Value() any {
+	switch o.which {
+	case 1:
+		return o.a
+	case 2:
+		return o.b
+	case 3:
+		return o.c
+	}
+	return nil
+}
+
+// IsZero reports whether o holds no value.
+func (o Or3[A, B, C]) IsZero() bool {
+	return o.which == 0
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o Or3[A, B, C]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.Value())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (o *Or3[A, B, C]) UnmarshalJSON(data []byte) error {
+	*o = Or3[A, B, C]{}
+	if isJSONNull(data) {
+		return nil
+	}
+	for _, strict := range []bool{true, false} {
+		if a, err := decodeAlternative[A](data, strict); err == nil {
+			*o = NewOr3A[A, B, C](a)
+			return nil
+		}
+		if b, err := decodeAlternative[B](data, strict); err == nil {
+			*o = NewOr3B[A, B, C](b)
+			return nil
+		}
+		if c, err := decodeAlternative[C](data, strict); err == nil {
+			*o = NewOr3C[A, B](c)
+			return nil
+		}
+	}
+	return fmt.Errorf("golsptoolkit: %s is neither a %s, a %s nor a %s", data, typeName[A](), typeName[B](), typeName[C]())
+}
+
+// decodeAlternative decodes data into a T. If strict is true, objects with
+// properties T does not define are rejected.
+func decodeAlternative[T any](data []byte, strict bool) (T, error) {
+	var v T
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if strict {
+		dec.DisallowUnknownFields()
+	}
+	err := dec.Decode(&v)
+	return v, err
+}
+
+// typeName returns the name of T for error messages.
+func typeName[T any]() string {
+	return fmt.Sprintf("%T", *new(T))
+}
@@ -0,0 +1,60 @@
+package golsptoolkit
+
+import (
+	"context"
+	"sync"
+)
+
+// PartialResults streams the result of a request to the client in chunks,
+// for requests such as textDocument/references or workspace/symbol whose
+// results can be reported as they are found.
+//
+// If the client sent a partial result token, every chunk passed to Send is
+// reported right away through $/progress and Result returns an empty list,
+// as the specification requires of the final response. Otherwise chunks are
+// collected and Result returns them all, to be sent as the single response.
+type PartialResults[T any] struct {
+	conn  *JSONRPCConn
+	token *ProgressToken
+
+	mu    sync.Mutex
+	items []T
+}
+
+// NewPartialResults returns a PartialResults reporting over conn on token,
+// usually the PartialResultToken of the request being answered. A nil token
+// collects the results for a single response.
+func NewPartialResults[T any](conn *JSONRPCConn, token *ProgressToken) *PartialResults[T] {
+	return &PartialResults[T]{conn: conn, token: token}
+}
+
+// Streaming reports whether chunks are sent to the client as they come.
+func (p *PartialResults[T]) Streaming() bool {
+	return p.token != nil
+}
+
+// Send reports a chunk of results. It must not be called once the response
+// has been sent.
+func (p *PartialResults[T]) Send(ctx context.Context, chunk ...T) error {
+	if len(chunk) == 0 {
+		return nil
+	}
+	if p.token == nil {
+		p.mu.Lock()
+		p.items = append(p.items, chunk...)
+		p.mu.Unlock()
+		return nil
+	}
+	return p.conn.Notify(ctx, MethodProgress, &ProgressParams[[]T]{Token: *p.token, Value: chunk})
+}
+
+// Result returns the result to answer the request with: the collected
+// chunks, or an empty list if they were streamed.
+func (p *PartialResults[T]) Result() []T {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.items == nil {
+		return []T{}
+	}
+	return p.items
+}
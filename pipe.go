@@ -0,0 +1,77 @@
+package golsptoolkit
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+)
+
+// Pipe returns two connected in-memory connections, typically used in tests
+// as the client and server ends of a session. Writes are buffered and never
+// block, so one side may send several messages before the other reads them.
+// Closing either end closes both.
+func Pipe() (client, server *StreamConn) {
+	clientToServer := newBufferPipe()
+	serverToClient := newBufferPipe()
+	closer := pipeCloser{clientToServer, serverToClient}
+
+	client = NewStreamConn(serverToClient, clientToServer, closer)
+	server = NewStreamConn(clientToServer, serverToClient, closer)
+	return client, server
+}
+
+// bufferPipe is a unidirectional, unbounded in-memory byte stream.
+type bufferPipe struct {
+	mu     sync.Mutex
+	cond   sync.Cond
+	buf    bytes.Buffer
+	closed bool
+}
+
+func newBufferPipe() *bufferPipe {
+	p := &bufferPipe{}
+	p.cond.L = &p.mu
+	return p
+}
+
+func (p *bufferPipe) Read(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for p.buf.Len() == 0 {
+		if p.closed {
+			return 0, io.EOF
+		}
+		p.cond.Wait()
+	}
+	return p.buf.Read(b)
+}
+
+func (p *bufferPipe) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return 0, io.ErrClosedPipe
+	}
+	n, err := p.buf.Write(b)
+	p.cond.Broadcast()
+	return n, err
+}
+
+func (p *bufferPipe) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	p.cond.Broadcast()
+	return nil
+}
+
+type pipeCloser []*bufferPipe
+
+func (pc pipeCloser) Close() error {
+	var errs []error
+	for _, p := range pc {
+		errs = append(errs, p.Close())
+	}
+	return errors.Join(errs...)
+}
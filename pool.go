@@ -0,0 +1,98 @@
+package golsptoolkit
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// Pools of message values, reused to spare the allocations of busy
+// notification streams such as textDocument/didChange or $/progress.
+var (
+	requestMessagePool      = sync.Pool{New: func() any { return new(RequestMessage) }}
+	responseMessagePool     = sync.Pool{New: func() any { return new(ResponseMessage) }}
+	notificationMessagePool = sync.Pool{New: func() any { return new(NotificationMessage) }}
+
+	wireMessagePool   = sync.Pool{New: func() any { return new(wireMessage) }}
+	messageFieldsPool = sync.Pool{New: func() any { return make(map[string]json.RawMessage, maxPooledFields) }}
+)
+
+// maxPooledFields is the largest field map returned to the pool; maps grown
+// by messages with many unknown members are left to the garbage collector.
+const maxPooledFields = 8
+
+// AcquireRequestMessage returns an empty RequestMessage from a pool, with
+// JSONRPC set. Return it with ReleaseRequestMessage once it is no longer
+// used.
+func AcquireRequestMessage() *RequestMessage {
+	m := requestMessagePool.Get().(*RequestMessage)
+	m.JSONRPC = JSONRPCVersion
+	return m
+}
+
+// ReleaseRequestMessage clears m and returns it to the pool. m must not be
+// used afterwards.
+func ReleaseRequestMessage(m *RequestMessage) {
+	*m = RequestMessage{}
+	requestMessagePool.Put(m)
+}
+
+// AcquireResponseMessage returns an empty ResponseMessage from a pool, with
+// JSONRPC set. Return it with ReleaseResponseMessage once it is no longer
+// used.
+func AcquireResponseMessage() *ResponseMessage {
+	m := responseMessagePool.Get().(*ResponseMessage)
+	m.JSONRPC = JSONRPCVersion
+	return m
+}
+
+// ReleaseResponseMessage clears m and returns it to the pool. m must not be
+// used afterwards.
+func ReleaseResponseMessage(m *ResponseMessage) {
+	*m = ResponseMessage{}
+	responseMessagePool.Put(m)
+}
+
+// AcquireNotificationMessage returns an empty NotificationMessage from a
+// pool, with JSONRPC set. Return it with ReleaseNotificationMessage once it
+// is no longer used.
+func AcquireNotificationMessage() *NotificationMessage {
+	m := notificationMessagePool.Get().(*NotificationMessage)
+	m.JSONRPC = JSONRPCVersion
+	return m
+}
+
+// ReleaseNotificationMessage clears m and returns it to the pool. m must not
+// be used afterwards.
+func ReleaseNotificationMessage(m *NotificationMessage) {
+	*m = NotificationMessage{}
+	notificationMessagePool.Put(m)
+}
+
+// acquireWireMessage returns an empty wireMessage from a pool, for decoding
+// an incoming message.
+func acquireWireMessage() *wireMessage {
+	return wireMessagePool.Get().(*wireMessage)
+}
+
+// releaseWireMessage clears m and returns it to the pool. The ID, Params,
+// Result and Error it referenced remain valid.
+func releaseWireMessage(m *wireMessage) {
+	*m = wireMessage{}
+	wireMessagePool.Put(m)
+}
+
+// acquireMessageFields returns an empty map from a pool, to decode the
+// members of an incoming message into.
+func acquireMessageFields() map[string]json.RawMessage {
+	return messageFieldsPool.Get().(map[string]json.RawMessage)
+}
+
+// releaseMessageFields clears fields and returns it to the pool. The values
+// it held remain valid.
+func releaseMessageFields(fields map[string]json.RawMessage) {
+	if len(fields) > maxPooledFields {
+		return
+	}
+	clear(fields)
+	messageFieldsPool.Put(fields)
+}
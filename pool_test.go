@@ -0,0 +1,43 @@
+package golsptoolkit
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+var benchmarkRequest = []byte(`{"jsonrpc":"2.0","id":7,"method":"textDocument/hover","params":{"textDocument":{"uri":"file:///a.go"},"position":{"line":3,"character":9}}}`)
+
+var benchmarkParams = &ProgressParams[LSPAny]{Token: NewStringProgressToken("t"), Value: "50%"}
+
+func BenchmarkDecodeMessage(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		msg, rerr := decodeMessage(benchmarkRequest)
+		if rerr != nil {
+			b.Fatal(rerr)
+		}
+		releaseWireMessage(msg)
+	}
+}
+
+func BenchmarkMarshalNotification(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		msg := &NotificationMessage{AbstractMessage: AbstractMessage{JSONRPC: JSONRPCVersion}, Method: MethodProgress, Params: benchmarkParams}
+		if _, err := json.Marshal(msg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalPooledNotification(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		msg := AcquireNotificationMessage()
+		msg.Method, msg.Params = MethodProgress, benchmarkParams
+		if _, err := json.Marshal(msg); err != nil {
+			b.Fatal(err)
+		}
+		ReleaseNotificationMessage(msg)
+	}
+}
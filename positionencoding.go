@@ -0,0 +1,135 @@
+package golsptoolkit
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// PositionEncodingKind represents how the character offsets of positions
+// are counted.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#positionEncodingKind
+type PositionEncodingKind = string
+
+const (
+	// PositionEncodingKindUTF8 counts UTF-8 code units, that is bytes.
+	PositionEncodingKindUTF8 PositionEncodingKind = "utf-8"
+
+	// PositionEncodingKindUTF16 counts UTF-16 code units. Every server
+	// must support it.
+	PositionEncodingKindUTF16 PositionEncodingKind = "utf-16"
+
+	// PositionEncodingKindUTF32 counts Unicode code points.
+	PositionEncodingKindUTF32 PositionEncodingKind = "utf-32"
+)
+
+// NegotiatePositionEncoding returns the position encoding to announce in
+// ServerCapabilities.PositionEncoding: the first encoding of the client's
+// general.positionEncodings, in the client's order of preference, that is
+// among supported. It falls back to PositionEncodingKindUTF16, which every
+// client and server supports. caps may be nil.
+//
+// The result is the encoding to pass to PositionToOffset and
+// OffsetToPosition for the rest of the session.
+func NegotiatePositionEncoding(caps *ClientCapabilities, supported ...PositionEncodingKind) PositionEncodingKind {
+	if caps == nil || caps.General == nil {
+		return PositionEncodingKindUTF16
+	}
+	for _, enc := range caps.General.PositionEncodings {
+		if slices.Contains(supported, enc) {
+			return enc
+		}
+	}
+	return PositionEncodingKindUTF16
+}
+
+// PositionToOffset returns the byte offset in text of pos, whose character
+// offset is counted in encoding. As the specification requires, a
+// character offset past the end of its line maps to the end of the line;
+// likewise a line past the end of text maps to the end of text. An offset
+// inside a character, such as between the halves of a UTF-16 surrogate
+// pair, maps to the start of the character.
+func PositionToOffset(text string, pos Position, encoding PositionEncodingKind) int {
+	start := 0
+	for line := UInteger(0); line < pos.Line; line++ {
+		_, next := nextLineBreak(text, start)
+		if next < 0 {
+			return len(text)
+		}
+		start = next
+	}
+	end, _ := nextLineBreak(text, start)
+
+	var units UInteger
+	for i := start; i < end; {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		units += encodedLen(r, size, encoding)
+		if units > pos.Character {
+			return i
+		}
+		i += size
+	}
+	return end
+}
+
+// OffsetToPosition returns the position of the byte offset in text, with
+// its character offset counted in encoding. offset is clamped to text; an
+// offset inside a line break or a character maps to the position before
+// it.
+func OffsetToPosition(text string, offset int, encoding PositionEncodingKind) Position {
+	offset = max(0, min(offset, len(text)))
+
+	var line UInteger
+	start := 0
+	for {
+		end, next := nextLineBreak(text, start)
+		if next < 0 || offset < next {
+			offset = min(offset, end)
+			break
+		}
+		line++
+		start = next
+	}
+
+	var units UInteger
+	for i := start; i < offset; {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if i+size > offset {
+			break
+		}
+		units += encodedLen(r, size, encoding)
+		i += size
+	}
+	return Position{Line: line, Character: units}
+}
+
+// nextLineBreak returns the byte offset of the first line break in text at
+// or after from, and the offset following it. A line break is "\n",
+// "\r\n" or "\r". If there is none, it returns len(text) and -1.
+func nextLineBreak(text string, from int) (end, next int) {
+	i := strings.IndexAny(text[from:], "\r\n")
+	if i < 0 {
+		return len(text), -1
+	}
+	end = from + i
+	if text[end] == '\r' && end+1 < len(text) && text[end+1] == '\n' {
+		return end, end + 2
+	}
+	return end, end + 1
+}
+
+// encodedLen returns the number of code units of r, decoded from size
+// bytes, in encoding. Unknown encodings count as UTF-16.
+func encodedLen(r rune, size int, encoding PositionEncodingKind) UInteger {
+	switch encoding {
+	case PositionEncodingKindUTF8:
+		return UInteger(size)
+	case PositionEncodingKindUTF32:
+		return 1
+	}
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,264 @@
+package golsptoolkit
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// ProgressManager reports work done progress from a server to its client.
+//
+// Progress is either server-initiated, with a token created through
+// window/workDoneProgress/create, or reported on a token the client sent
+// along with a request. When the client did not announce the
+// window.workDoneProgress capability, server-initiated progress is a no-op,
+// so callers need not check the capability themselves.
+//
+// To honor cancellation from the user, register the manager on the Mux
+// serving the connection with Register.
+type ProgressManager struct {
+	conn      *JSONRPCConn
+	supported bool
+	seq       atomic.Int64
+
+	mu     sync.Mutex
+	active map[ProgressToken]*Progress
+}
+
+// NewProgressManager returns a ProgressManager sending progress over conn.
+// clientSupport reports whether the client announced the
+// window.workDoneProgress capability.
+func NewProgressManager(conn *JSONRPCConn, clientSupport bool) *ProgressManager {
+	return &ProgressManager{
+		conn:      conn,
+		supported: clientSupport,
+		active:    make(map[ProgressToken]*Progress),
+	}
+}
+
+// Register registers the handler of window/workDoneProgress/cancel on mux.
+func (m *ProgressManager) Register(mux *Mux) {
+	HandleNotification(mux, MethodWorkDoneProgressCancel, func(_ context.Context, params WorkDoneProgressCancelParams) error {
+		m.Cancel(params.Token)
+		return nil
+	})
+}
+
+// Cancel cancels the context of the progress with the given token, as if the
+// user had canceled it.
+func (m *ProgressManager) Cancel(token ProgressToken) {
+	m.mu.Lock()
+	p := m.active[token]
+	m.mu.Unlock()
+	if p != nil {
+		p.cancel()
+	}
+}
+
+// Start creates a progress token on the client and begins reporting on it.
+// The returned Progress must be ended with End. If the client does not
+// support server-initiated progress, Start returns a Progress whose methods
+// do nothing.
+func (m *ProgressManager) Start(ctx context.Context, begin WorkDoneProgressBegin) (*Progress, error) {
+	if !m.supported {
+		return m.noop(ctx), nil
+	}
+
+	token := NewStringProgressToken("golsptoolkit/" + strconv.FormatInt(m.seq.Add(1), 10))
+	if err := m.conn.Call(ctx, MethodWorkDoneProgressCreate, &WorkDoneProgressCreateParams{Token: token}, nil); err != nil {
+		return nil, err
+	}
+	return m.begin(ctx, token, begin)
+}
+
+// Resume begins reporting on a token sent by the client, such as the
+// WorkDoneToken of a request. A nil token yields a Progress whose methods do
+// nothing.
+func (m *ProgressManager) Resume(ctx context.Context, token *ProgressToken, begin WorkDoneProgressBegin) (*Progress, error) {
+	if token == nil {
+		return m.noop(ctx), nil
+	}
+	return m.begin(ctx, *token, begin)
+}
+
+func (m *ProgressManager) begin(ctx context.Context, token ProgressToken, begin WorkDoneProgressBegin) (*Progress, error) {
+	pctx, cancel := context.WithCancel(ctx)
+	p := &Progress{m: m, token: token, ctx: pctx, cancel: cancel}
+
+	m.mu.Lock()
+	m.active[token] = p
+	m.mu.Unlock()
+
+	begin.Kind = WorkDoneProgressKindBegin
+	if err := p.send(ctx, begin); err != nil {
+		p.forget()
+		return nil, err
+	}
+	return p, nil
+}
+
+func (m *ProgressManager) noop(ctx context.Context) *Progress {
+	pctx, cancel := context.WithCancel(ctx)
+	return &Progress{ctx: pctx, cancel: cancel}
+}
+
+// Progress is a work done progress in flight, started by a ProgressManager.
+type Progress struct {
+	m      *ProgressManager
+	token  ProgressToken
+	ctx    context.Context
+	cancel context.CancelFunc
+	ended  atomic.Bool
+}
+
+// Token returns the token progress is reported on, and false if the
+// Progress does nothing.
+func (p *Progress) Token() (ProgressToken, bool) {
+	return p.token, p.m != nil
+}
+
+// Context returns a context that is canceled when the user cancels the
+// operation or the progress ends. Long-running work should stop when it is
+// done.
+func (p *Progress) Context() context.Context {
+	return p.ctx
+}
+
+// Report reports progress on the operation.
+func (p *Progress) Report(ctx context.Context, report WorkDoneProgressReport) error {
+	if p.m == nil || p.ended.Load() {
+		return nil
+	}
+	report.Kind = WorkDoneProgressKindReport
+	return p.send(ctx, report)
+}
+
+// End reports the end of the operation. Later calls to Report and End do
+// nothing.
+func (p *Progress) End(ctx context.Context, message string) error {
+	if !p.ended.CompareAndSwap(false, true) {
+		return nil
+	}
+	p.cancel()
+	if p.m == nil {
+		return nil
+	}
+	p.forget()
+	return p.send(ctx, WorkDoneProgressEnd{Kind: WorkDoneProgressKindEnd, Message: message})
+}
+
+func (p *Progress) send(ctx context.Context, value any) error {
+	return p.m.conn.Notify(ctx, MethodProgress, &ProgressParams[any]{Token: p.token, Value: value})
+}
+
+func (p *Progress) forget() {
+	p.m.mu.Lock()
+	delete(p.m.active, p.token)
+	p.m.mu.Unlock()
+}
+
+// WorkDoneProgressListener receives the work done progress reported on one
+// token. Nil callbacks are skipped.
+type WorkDoneProgressListener struct {
+	OnBegin  func(WorkDoneProgressBegin)
+	OnReport func(WorkDoneProgressReport)
+	OnEnd    func(WorkDoneProgressEnd)
+}
+
+// ProgressRouter routes incoming $/progress notifications to listeners
+// registered per token, decoding each value into the type the listener
+// expects. It is the receiving counterpart of ProgressManager and
+// PartialResults, for clients and test harnesses.
+//
+// Notifications for tokens without a listener are dropped.
+type ProgressRouter struct {
+	seq atomic.Int64
+
+	mu        sync.Mutex
+	listeners map[ProgressToken]*progressListener
+}
+
+// progressListener receives the values reported on one token.
+type progressListener struct {
+	fn func(value json.RawMessage)
+}
+
+// NewProgressRouter returns a ProgressRouter without listeners.
+func NewProgressRouter() *ProgressRouter {
+	return &ProgressRouter{listeners: make(map[ProgressToken]*progressListener)}
+}
+
+// Register registers the handler of $/progress on mux.
+func (r *ProgressRouter) Register(mux *Mux) {
+	HandleNotification(mux, MethodProgress, func(_ context.Context, params ProgressParams[json.RawMessage]) error {
+		r.mu.Lock()
+		l := r.listeners[params.Token]
+		r.mu.Unlock()
+		if l != nil {
+			l.fn(params.Value)
+		}
+		return nil
+	})
+}
+
+// NewToken returns a token not used by earlier calls, to send as the
+// WorkDoneToken or PartialResultToken of a request.
+func (r *ProgressRouter) NewToken() ProgressToken {
+	return NewStringProgressToken("golsptoolkit/" + strconv.FormatInt(r.seq.Add(1), 10))
+}
+
+// ListenWorkDone routes the work done progress reported on token to l until
+// the end of the progress or until the returned stop function is called.
+func (r *ProgressRouter) ListenWorkDone(token ProgressToken, l WorkDoneProgressListener) (stop func()) {
+	return r.listen(token, func(value json.RawMessage, stop func()) {
+		v, err := decodeParams[WorkDoneProgressValue](value)
+		switch {
+		case err != nil:
+		case v.Begin != nil:
+			if l.OnBegin != nil {
+				l.OnBegin(*v.Begin)
+			}
+		case v.Report != nil:
+			if l.OnReport != nil {
+				l.OnReport(*v.Report)
+			}
+		case v.End != nil:
+			stop()
+			if l.OnEnd != nil {
+				l.OnEnd(*v.End)
+			}
+		}
+	})
+}
+
+// ListenPartialResults routes the partial results reported on token to fn,
+// one chunk at a time, until the returned stop function is called. Call stop
+// once the response to the request has arrived.
+func ListenPartialResults[T any](r *ProgressRouter, token ProgressToken, fn func(chunk []T)) (stop func()) {
+	return r.listen(token, func(value json.RawMessage, _ func()) {
+		if chunk, err := decodeParams[[]T](value); err == nil {
+			fn(chunk)
+		}
+	})
+}
+
+// listen installs fn as the listener for token, replacing any previous one,
+// and returns a function removing it. fn is passed the same function.
+func (r *ProgressRouter) listen(token ProgressToken, fn func(value json.RawMessage, stop func())) (stop func()) {
+	l := new(progressListener)
+	stop = func() {
+		r.mu.Lock()
+		if r.listeners[token] == l {
+			delete(r.listeners, token)
+		}
+		r.mu.Unlock()
+	}
+	l.fn = func(value json.RawMessage) { fn(value, stop) }
+
+	r.mu.Lock()
+	r.listeners[token] = l
+	r.mu.Unlock()
+	return stop
+}
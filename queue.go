@@ -0,0 +1,271 @@
+package golsptoolkit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// ErrQueueFull is returned by QueueConn.WriteMessage under the
+// BackpressureError policy when the queue is full.
+var ErrQueueFull = errors.New("golsptoolkit: write queue full")
+
+// DefaultMaxQueued is the default bound on the messages a QueueConn holds.
+const DefaultMaxQueued = 1024
+
+// DefaultFlushTimeout is the default bound on how long QueueConn.Close
+// waits for the queued messages to be written.
+const DefaultFlushTimeout = 5 * time.Second
+
+// BackpressurePolicy selects what QueueConn.WriteMessage does when the
+// queue is full.
+type BackpressurePolicy int
+
+const (
+	// BackpressureBlock waits until there is room in the queue, or the
+	// context is done.
+	BackpressureBlock BackpressurePolicy = iota
+
+	// BackpressureDropOldest discards the oldest queued notification whose
+	// method is listed in QueueConn.Droppable to make room, and waits as
+	// BackpressureBlock does if there is none.
+	BackpressureDropOldest
+
+	// BackpressureError fails the write with ErrQueueFull.
+	BackpressureError
+)
+
+// QueueConn wraps a Conn with a bounded queue of outgoing messages, written
+// in order by a single goroutine, so that a slow peer stalls neither the
+// handlers writing to it nor memory: once the queue is full, Policy decides
+// whether writers wait, the least valuable notifications are dropped, or
+// writes fail.
+//
+// WriteMessage returns as soon as a copy of the message is queued, so the
+// caller may reuse its buffer. An error writing to the wrapped Conn is
+// returned by WriteMessage from then on, including to writers waiting for
+// room, and discards the queue. Close writes out what is queued, for at most
+// FlushTimeout, before closing the wrapped Conn.
+type QueueConn struct {
+	Conn
+
+	// MaxMessages bounds the number of queued messages. Zero means
+	// DefaultMaxQueued.
+	MaxMessages int
+
+	// MaxBytes bounds the total size of the queued messages. Zero means no
+	// limit. A single message larger than MaxBytes is queued when the queue
+	// is empty.
+	MaxBytes int
+
+	// Policy is applied when the queue is full.
+	Policy BackpressurePolicy
+
+	// Droppable lists the notification methods whose messages
+	// BackpressureDropOldest may discard, such as "$/progress" or
+	// "window/logMessage".
+	Droppable map[string]bool
+
+	// FlushTimeout bounds how long Close waits for the queued messages to
+	// be written to a stalled peer. Once it passes, the write in progress
+	// is canceled and the rest of the queue is discarded. Zero means
+	// DefaultFlushTimeout.
+	FlushTimeout time.Duration
+
+	startOnce sync.Once
+	closeOnce sync.Once
+	mu        sync.Mutex
+	queue     []queuedMessage
+	size      int
+	err       error
+	closing   bool
+	ready     chan struct{}
+	space     chan struct{}
+	done      chan struct{}
+	flushed   chan struct{}
+
+	// failed is closed once a write to the wrapped Conn fails.
+	failed chan struct{}
+
+	// writeCtx is the context of the writes to the wrapped Conn, canceled
+	// by Close once the flush times out.
+	writeCtx    context.Context
+	cancelWrite context.CancelFunc
+}
+
+// NewQueueConn returns a QueueConn wrapping c.
+func NewQueueConn(c Conn) *QueueConn {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &QueueConn{
+		Conn:        c,
+		ready:       make(chan struct{}, 1),
+		space:       make(chan struct{}, 1),
+		done:        make(chan struct{}),
+		flushed:     make(chan struct{}),
+		failed:      make(chan struct{}),
+		writeCtx:    ctx,
+		cancelWrite: cancel,
+	}
+}
+
+// queuedMessage is a message waiting in a QueueConn.
+type queuedMessage struct {
+	body []byte
+
+	// droppable reports whether BackpressureDropOldest may discard the
+	// message.
+	droppable bool
+}
+
+// WriteMessage queues a copy of body to be written.
+func (c *QueueConn) WriteMessage(ctx context.Context, body []byte) error {
+	c.startOnce.Do(func() { go c.writeLoop(c.writeCtx) })
+
+	msg := queuedMessage{body: bytes.Clone(body)}
+	if c.Policy == BackpressureDropOldest {
+		msg.droppable = c.droppable(body)
+	}
+	for {
+		c.mu.Lock()
+		switch {
+		case c.err != nil:
+			c.mu.Unlock()
+			return c.err
+		case c.closing:
+			c.mu.Unlock()
+			return net.ErrClosed
+		case !c.full(body) || c.Policy == BackpressureDropOldest && c.makeRoom(body):
+			c.queue = append(c.queue, msg)
+			c.size += len(body)
+			c.mu.Unlock()
+			signal(c.ready)
+			return nil
+		case c.Policy == BackpressureError:
+			c.mu.Unlock()
+			return ErrQueueFull
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-c.space:
+		case <-c.done:
+		case <-c.failed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// full reports whether body does not fit in the queue.
+func (c *QueueConn) full(body []byte) bool {
+	limit := c.MaxMessages
+	if limit <= 0 {
+		limit = DefaultMaxQueued
+	}
+	if len(c.queue) >= limit {
+		return true
+	}
+	return c.MaxBytes > 0 && len(c.queue) > 0 && c.size+len(body) > c.MaxBytes
+}
+
+// droppable reports whether body is a notification whose method is listed
+// in Droppable.
+func (c *QueueConn) droppable(body []byte) bool {
+	var msg struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	return json.Unmarshal(body, &msg) == nil && msg.ID == nil && c.Droppable[msg.Method]
+}
+
+// makeRoom discards droppable messages, oldest first, until body fits in
+// the queue, and reports whether it does.
+func (c *QueueConn) makeRoom(body []byte) bool {
+	for i := 0; i < len(c.queue) && c.full(body); {
+		queued := c.queue[i]
+		if !queued.droppable {
+			i++
+			continue
+		}
+		c.size -= len(queued.body)
+		c.queue = append(c.queue[:i], c.queue[i+1:]...)
+	}
+	return !c.full(body)
+}
+
+func (c *QueueConn) writeLoop(ctx context.Context) {
+	defer close(c.flushed)
+	for {
+		c.mu.Lock()
+		if len(c.queue) == 0 {
+			closing := c.closing
+			c.mu.Unlock()
+			if closing {
+				return
+			}
+			select {
+			case <-c.ready:
+			case <-c.done:
+			}
+			continue
+		}
+		body := c.queue[0].body
+		c.queue[0] = queuedMessage{}
+		c.queue = c.queue[1:]
+		c.size -= len(body)
+		c.mu.Unlock()
+		signal(c.space)
+
+		if err := c.Conn.WriteMessage(ctx, body); err != nil {
+			c.mu.Lock()
+			c.err = err
+			c.queue, c.size = nil, 0
+			c.mu.Unlock()
+			close(c.failed)
+			return
+		}
+	}
+}
+
+// Close writes out the queued messages, waiting for at most FlushTimeout,
+// and closes the wrapped Conn.
+func (c *QueueConn) Close() error {
+	c.closeOnce.Do(func() {
+		c.mu.Lock()
+		c.closing = true
+		c.mu.Unlock()
+		close(c.done)
+	})
+	defer c.cancelWrite()
+
+	started := true
+	c.startOnce.Do(func() { started = false })
+	if started {
+		timeout := c.FlushTimeout
+		if timeout <= 0 {
+			timeout = DefaultFlushTimeout
+		}
+		t := time.NewTimer(timeout)
+		defer t.Stop()
+		select {
+		case <-c.flushed:
+		case <-t.C:
+			// The peer is stalled. Canceling the write and closing the
+			// wrapped Conn unblocks the write loop.
+			c.cancelWrite()
+		}
+	}
+	return c.Conn.Close()
+}
+
+// signal wakes up a goroutine waiting on ch without blocking.
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,111 @@
+package golsptoolkit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stallConn is a Conn whose writes block until fail is closed, then fail.
+type stallConn struct {
+	Conn
+	fail chan struct{}
+
+	mu      sync.Mutex
+	written [][]byte
+}
+
+var errStalled = errors.New("write failed")
+
+func (c *stallConn) WriteMessage(ctx context.Context, body []byte) error {
+	c.mu.Lock()
+	c.written = append(c.written, body)
+	c.mu.Unlock()
+	select {
+	case <-c.fail:
+		return errStalled
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *stallConn) Close() error { return nil }
+
+func TestQueueConnBlockedWriterFails(t *testing.T) {
+	sc := &stallConn{fail: make(chan struct{})}
+	q := NewQueueConn(sc)
+	q.MaxMessages = 1
+	defer q.Close()
+
+	ctx := context.Background()
+	// The first message is taken by the write loop and stalls, the second
+	// fills the queue.
+	if err := q.WriteMessage(ctx, []byte(`{"n":1}`)); err != nil {
+		t.Fatal(err)
+	}
+	for {
+		q.mu.Lock()
+		n := len(q.queue)
+		q.mu.Unlock()
+		if n == 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := q.WriteMessage(ctx, []byte(`{"n":2}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	errs := make(chan error, 2)
+	for range 2 {
+		go func() { errs <- q.WriteMessage(ctx, []byte(`{"n":3}`)) }()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(sc.fail)
+
+	for range 2 {
+		select {
+		case err := <-errs:
+			if !errors.Is(err, errStalled) {
+				t.Fatalf("blocked WriteMessage = %v, want %v", err, errStalled)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("blocked WriteMessage did not return after the write failed")
+		}
+	}
+}
+
+func TestQueueConnCopiesBody(t *testing.T) {
+	sc := &stallConn{fail: make(chan struct{})}
+	q := NewQueueConn(sc)
+	q.MaxMessages = 2
+
+	buf := []byte(`{"n":1}`)
+	if err := q.WriteMessage(context.Background(), buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.WriteMessage(context.Background(), buf); err != nil {
+		t.Fatal(err)
+	}
+	copy(buf, `{"n":9}`)
+
+	q.mu.Lock()
+	for _, msg := range q.queue {
+		if string(msg.body) != `{"n":1}` {
+			t.Errorf("queued %s, want the body as it was when queued", msg.body)
+		}
+	}
+	q.mu.Unlock()
+	sc.mu.Lock()
+	for _, body := range sc.written {
+		if string(body) != `{"n":1}` {
+			t.Errorf("written %s, want the body as it was when queued", body)
+		}
+	}
+	sc.mu.Unlock()
+
+	close(sc.fail)
+	q.Close()
+}
@@ -0,0 +1,379 @@
+package golsptoolkit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"sync"
+	"time"
+)
+
+// Default backoff bounds used by ReconnectingConn.
+const (
+	DefaultInitialBackoff = 100 * time.Millisecond
+	DefaultMaxBackoff     = 30 * time.Second
+)
+
+// ReconnectEvent describes a reconnection attempt made by a ReconnectingConn.
+type ReconnectEvent struct {
+	// Attempt counts the dial attempts since the connection was lost,
+	// starting at 1.
+	Attempt int
+
+	// Cause is the error that made the connection unusable.
+	Cause error
+
+	// Err is the dial or replay error of a failed attempt, or nil when the
+	// connection was re-established.
+	Err error
+}
+
+// ReconnectingConn is a client-side Conn that transparently re-dials the
+// server when the underlying connection is lost.
+//
+// The most recent initialize request and initialized notification written
+// through the connection are remembered and replayed on every new
+// connection, so the server sees a complete handshake. The replayed
+// initialize request carries an id of its own, and the response to it is
+// consumed internally and never returned by ReadMessage.
+//
+// Requests that were in flight when the connection dropped are not resent;
+// their responses will never arrive.
+type ReconnectingConn struct {
+	// InitialBackoff is the delay before the second dial attempt. Zero means
+	// DefaultInitialBackoff. The delay doubles after every failed attempt.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between attempts. Zero means
+	// DefaultMaxBackoff.
+	MaxBackoff time.Duration
+
+	// MaxAttempts limits the dial attempts per reconnection. Zero means no
+	// limit.
+	MaxAttempts int
+
+	// OnReconnect, if non-nil, is called after every reconnection attempt.
+	OnReconnect func(ReconnectEvent)
+
+	dial   func(ctx context.Context) (Conn, error)
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu          sync.Mutex
+	conn        Conn
+	gen         int
+	initialize  []byte
+	initialized []byte
+	replayID    json.RawMessage
+
+	// redial is non-nil while a reconnection is in progress, and closed
+	// when it ends with redialErr.
+	redial    chan struct{}
+	redialErr error
+}
+
+// NewReconnectingConn dials the first connection with dial and returns a
+// ReconnectingConn that uses dial again whenever the connection is lost.
+func NewReconnectingConn(ctx context.Context, dial func(ctx context.Context) (Conn, error)) (*ReconnectingConn, error) {
+	conn, err := dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rctx, cancel := context.WithCancel(context.Background())
+	return &ReconnectingConn{
+		dial:   dial,
+		ctx:    rctx,
+		cancel: cancel,
+		conn:   conn,
+	}, nil
+}
+
+// ReadMessage reads the next message, reconnecting as needed. Errors caused
+// by ctx, and messages rejected without harm to the stream such as
+// ErrContentTooLarge, are returned without reconnecting.
+func (c *ReconnectingConn) ReadMessage(ctx context.Context) ([]byte, error) {
+	for {
+		conn, gen := c.current()
+		body, err := conn.ReadMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil || !isTransportError(err) {
+				return nil, err
+			}
+			if rerr := c.reconnect(ctx, gen, err, true); rerr != nil {
+				return nil, rerr
+			}
+			continue
+		}
+
+		if c.consumeReplayResponse(conn, body) {
+			continue
+		}
+		return body, nil
+	}
+}
+
+// WriteMessage writes body, reconnecting and retrying once if the write
+// fails. Handshake messages are recorded for replay.
+func (c *ReconnectingConn) WriteMessage(ctx context.Context, body []byte) error {
+	method := messageMethod(body)
+	if method == MethodInitialized {
+		c.mu.Lock()
+		c.initialized = bytes.Clone(body)
+		c.mu.Unlock()
+	}
+
+	conn, gen := c.current()
+	err := conn.WriteMessage(ctx, body)
+	if err != nil && ctx.Err() == nil {
+		// A failed initialize request is resent as is below, so that its
+		// response reaches the caller, instead of being replayed.
+		if rerr := c.reconnect(ctx, gen, err, method != MethodInitialize); rerr != nil {
+			return rerr
+		}
+		if method == MethodInitialized {
+			// The replay delivers it on the new connection.
+			return nil
+		}
+		conn, _ = c.current()
+		err = conn.WriteMessage(ctx, body)
+	}
+	if err == nil && method == MethodInitialize {
+		c.mu.Lock()
+		c.initialize = bytes.Clone(body)
+		c.initialized = nil
+		c.mu.Unlock()
+	}
+	return err
+}
+
+// Close closes the current connection and stops any reconnection in
+// progress.
+func (c *ReconnectingConn) Close() error {
+	c.cancel()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn.Close()
+}
+
+func (c *ReconnectingConn) current() (Conn, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn, c.gen
+}
+
+// reconnect replaces the connection of generation gen, which failed with
+// cause, replaying the handshake on the new one if replay is set. If
+// another goroutine already replaced it, reconnect returns immediately; if
+// another goroutine is replacing it, reconnect waits for it to finish.
+//
+// The lock is not held while dialing or backing off, and giving up on ctx
+// leaves the connection to be replaced by the next caller.
+func (c *ReconnectingConn) reconnect(ctx context.Context, gen int, cause error, replay bool) error {
+	for {
+		c.mu.Lock()
+		if c.ctx.Err() != nil {
+			c.mu.Unlock()
+			return net.ErrClosed
+		}
+		if c.gen != gen {
+			c.mu.Unlock()
+			return nil
+		}
+		if ch := c.redial; ch != nil {
+			c.mu.Unlock()
+			select {
+			case <-ch:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			c.mu.Lock()
+			err := c.redialErr
+			c.mu.Unlock()
+			if err != nil && !isContextError(err) {
+				return err
+			}
+			// Either the connection was replaced, or the goroutine
+			// replacing it gave up on its own context: check again.
+			continue
+		}
+
+		ch := make(chan struct{})
+		c.redial = ch
+		old := c.conn
+		c.mu.Unlock()
+		old.Close()
+
+		err := c.redialLoop(ctx, cause, replay)
+		c.mu.Lock()
+		c.redial = nil
+		c.redialErr = err
+		c.mu.Unlock()
+		close(ch)
+		return err
+	}
+}
+
+// redialLoop dials until a new connection is established, installing it as
+// the current one, or until the attempts run out.
+func (c *ReconnectingConn) redialLoop(ctx context.Context, cause error, replay bool) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(c.ctx, cancel)
+	defer stop()
+
+	backoff := c.InitialBackoff
+	if backoff <= 0 {
+		backoff = DefaultInitialBackoff
+	}
+	maxBackoff := c.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultMaxBackoff
+	}
+
+	for attempt := 1; ; attempt++ {
+		conn, err := c.dial(ctx)
+		if err == nil {
+			var replayID json.RawMessage
+			if replay {
+				replayID, err = c.replay(ctx, conn)
+			}
+			if err == nil {
+				c.mu.Lock()
+				c.conn = conn
+				c.gen++
+				c.replayID = replayID
+				c.mu.Unlock()
+				c.notify(ReconnectEvent{Attempt: attempt, Cause: cause})
+				return nil
+			}
+			conn.Close()
+		}
+		if c.ctx.Err() != nil {
+			return net.ErrClosed
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		c.notify(ReconnectEvent{Attempt: attempt, Cause: cause, Err: err})
+
+		if c.MaxAttempts > 0 && attempt >= c.MaxAttempts {
+			return cause
+		}
+
+		// Full jitter keeps a fleet of clients from redialing in lockstep.
+		delay := time.Duration(rand.Int64N(int64(backoff)) + 1)
+		t := time.NewTimer(delay)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			if c.ctx.Err() != nil {
+				return net.ErrClosed
+			}
+			return ctx.Err()
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// replay resends the recorded initialize request on conn, under an id of
+// its own so that its response is told apart from those the application
+// waits for, and returns that id. The initialized notification follows once
+// the server has answered.
+func (c *ReconnectingConn) replay(ctx context.Context, conn Conn) (json.RawMessage, error) {
+	c.mu.Lock()
+	initialize := c.initialize
+	gen := c.gen
+	c.mu.Unlock()
+	if initialize == nil {
+		return nil, nil
+	}
+
+	var msg map[string]json.RawMessage
+	if err := json.Unmarshal(initialize, &msg); err != nil {
+		return nil, err
+	}
+	id, err := json.Marshal(fmt.Sprintf("golsptoolkit/replay/%d", gen+1))
+	if err != nil {
+		return nil, err
+	}
+	msg["id"] = id
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.WriteMessage(ctx, body); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+func (c *ReconnectingConn) notify(ev ReconnectEvent) {
+	if c.OnReconnect != nil {
+		c.OnReconnect(ev)
+	}
+}
+
+// messageMethod returns the method of the message body, or "" if it has
+// none.
+func messageMethod(body []byte) string {
+	var msg struct {
+		Method string `json:"method"`
+	}
+	json.Unmarshal(body, &msg)
+	return msg.Method
+}
+
+// isTransportError reports whether err, returned by ReadMessage, means the
+// connection is unusable, rather than that a single message was rejected
+// and skipped.
+func isTransportError(err error) bool {
+	return !errors.Is(err, ErrContentTooLarge) &&
+		!errors.Is(err, ErrUnsupportedCharset) &&
+		!errors.Is(err, ErrCorruptCompression)
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// consumeReplayResponse reports whether body answers a replayed initialize
+// request, in which case it completes the handshake on conn. The lock is
+// not held while writing, so a stalled connection does not block other
+// writers or a reconnection.
+func (c *ReconnectingConn) consumeReplayResponse(conn Conn, body []byte) bool {
+	c.mu.Lock()
+	replayID := c.replayID
+	c.mu.Unlock()
+	if replayID == nil {
+		return false
+	}
+
+	var msg struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	if json.Unmarshal(body, &msg) != nil || msg.Method != "" || !bytes.Equal(msg.ID, replayID) {
+		return false
+	}
+
+	c.mu.Lock()
+	if !bytes.Equal(c.replayID, replayID) {
+		// The connection was replaced meanwhile; its own replay completes
+		// the handshake.
+		c.mu.Unlock()
+		return true
+	}
+	c.replayID = nil
+	initialized := c.initialized
+	c.mu.Unlock()
+
+	if initialized != nil {
+		conn.WriteMessage(c.ctx, initialized)
+	}
+	return true
+}
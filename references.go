@@ -0,0 +1,134 @@
+package golsptoolkit
+
+// Methods of references and document highlights.
+const (
+	MethodTextDocumentReferences        = "textDocument/references"
+	MethodTextDocumentDocumentHighlight = "textDocument/documentHighlight"
+)
+
+// ReferenceOptions represents the references options a server announces in
+// ServerCapabilities.ReferencesProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#referenceOptions
+type ReferenceOptions struct {
+	WorkDoneProgressOptions
+}
+
+// ReferenceRegistrationOptions are the options of a dynamic registration of
+// references.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#referenceRegistrationOptions
+type ReferenceRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	ReferenceOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (ReferenceRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentReferences
+}
+
+// ReferenceParams are the parameters of the textDocument/references
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#referenceParams
+type ReferenceParams struct {
+	TextDocumentPositionParams
+	WorkDoneProgressParams
+	PartialResultParams
+
+	Context ReferenceContext `json:"context"`
+}
+
+// ReferenceContext is the context of a textDocument/references request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#referenceContext
+type ReferenceContext struct {
+	// IncludeDeclaration reports whether the declaration of the symbol
+	// should be included in the result.
+	IncludeDeclaration bool `json:"includeDeclaration"`
+}
+
+// DocumentHighlightOptions represents the document highlight options a server
+// announces in ServerCapabilities.DocumentHighlightProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentHighlightOptions
+type DocumentHighlightOptions struct {
+	WorkDoneProgressOptions
+}
+
+// DocumentHighlightRegistrationOptions are the options of a dynamic
+// registration of document highlight.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentHighlightRegistrationOptions
+type DocumentHighlightRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	DocumentHighlightOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (DocumentHighlightRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentDocumentHighlight
+}
+
+// DocumentHighlightParams are the parameters of the
+// textDocument/documentHighlight request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentHighlightParams
+type DocumentHighlightParams struct {
+	TextDocumentPositionParams
+	WorkDoneProgressParams
+	PartialResultParams
+}
+
+// DocumentHighlightKind represents the kind of a document highlight.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentHighlightKind
+type DocumentHighlightKind UInteger
+
+const (
+	// DocumentHighlightKindText is a textual occurrence.
+	DocumentHighlightKindText DocumentHighlightKind = 1
+
+	// DocumentHighlightKindRead is read access of a symbol, such as
+	// reading a variable.
+	DocumentHighlightKindRead DocumentHighlightKind = 2
+
+	// DocumentHighlightKindWrite is write access of a symbol, such as
+	// writing to a variable.
+	DocumentHighlightKindWrite DocumentHighlightKind = 3
+)
+
+var documentHighlightKindNames = enumNames{"DocumentHighlightKind", []string{
+	DocumentHighlightKindText:  "Text",
+	DocumentHighlightKindRead:  "Read",
+	DocumentHighlightKindWrite: "Write",
+}}
+
+// String returns the name of k, such as "Text".
+func (k DocumentHighlightKind) String() string {
+	return documentHighlightKindNames.name(int64(k))
+}
+
+// IsValid reports whether k is one of the defined document highlight kinds.
+func (k DocumentHighlightKind) IsValid() bool {
+	return documentHighlightKindNames.valid(int64(k))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Undefined values are rejected.
+func (k *DocumentHighlightKind) UnmarshalJSON(data []byte) error {
+	return decodeEnum(data, k, documentHighlightKindNames)
+}
+
+// DocumentHighlight represents a range inside a document that deserves
+// special attention, such as an occurrence of the symbol under the cursor.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#documentHighlight
+type DocumentHighlight struct {
+	// Range is the range the highlight applies to.
+	Range Range `json:"range"`
+
+	// Kind is the kind of the highlight. If omitted, it is
+	// DocumentHighlightKindText.
+	Kind DocumentHighlightKind `json:"kind,omitempty"`
+}
@@ -0,0 +1,104 @@
+package golsptoolkit
+
+import "context"
+
+// TextDocumentRegistrationOptions is embedded in the registration options
+// of text document features.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocumentRegistrationOptions
+type TextDocumentRegistrationOptions struct {
+	// DocumentSelector selects the documents the registration applies
+	// to. If nil, the selector provided on the client side is used.
+	DocumentSelector DocumentSelector `json:"documentSelector"`
+}
+
+// StaticRegistrationOptions is embedded in the registration options of
+// features that can be registered statically, in the server capabilities,
+// with an id that allows unregistering them later.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#staticRegistrationOptions
+type StaticRegistrationOptions struct {
+	// ID identifies the registration.
+	ID string `json:"id,omitempty"`
+}
+
+// Methods of capability registration.
+const (
+	MethodClientRegisterCapability   = "client/registerCapability"
+	MethodClientUnregisterCapability = "client/unregisterCapability"
+)
+
+// Registration represents a capability registered dynamically by the
+// server.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#registration
+type Registration struct {
+	// ID identifies the registration, to unregister it later.
+	ID string `json:"id"`
+
+	// Method is the method or capability to register for.
+	Method string `json:"method"`
+
+	// RegisterOptions are the options of the registration, typically a
+	// RegistrationOptions.
+	RegisterOptions LSPAny `json:"registerOptions,omitempty"`
+}
+
+// RegistrationOptions is implemented by the typed registration options of
+// features, each naming the method it registers.
+type RegistrationOptions interface {
+	RegistrationMethod() string
+}
+
+// NewRegistration returns the registration of options under id, for the
+// method options is registered with.
+func NewRegistration(id string, options RegistrationOptions) Registration {
+	return Registration{ID: id, Method: options.RegistrationMethod(), RegisterOptions: options}
+}
+
+// RegistrationParams are the parameters of the client/registerCapability
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#registrationParams
+type RegistrationParams struct {
+	Registrations []Registration `json:"registrations"`
+}
+
+// Unregistration represents the removal of a capability registered
+// dynamically.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#unregistration
+type Unregistration struct {
+	// ID is the ID of the registration to remove.
+	ID string `json:"id"`
+
+	// Method is the method or capability to unregister.
+	Method string `json:"method"`
+}
+
+// UnregistrationParams are the parameters of the
+// client/unregisterCapability request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#unregistrationParams
+type UnregistrationParams struct {
+	// Unregisterations are the registrations to remove. The property name
+	// is misspelled in the specification and kept for compatibility.
+	Unregisterations []Unregistration `json:"unregisterations"`
+}
+
+// RegisterCapability registers registrations with the client through the
+// client/registerCapability request. The client must have announced
+// dynamicRegistration for each feature.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#client_registerCapability
+func RegisterCapability(ctx context.Context, conn *JSONRPCConn, registrations ...Registration) error {
+	return conn.Call(ctx, MethodClientRegisterCapability, &RegistrationParams{Registrations: registrations}, nil)
+}
+
+// UnregisterCapability removes registrations made earlier through the
+// client/unregisterCapability request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#client_unregisterCapability
+func UnregisterCapability(ctx context.Context, conn *JSONRPCConn, unregistrations ...Unregistration) error {
+	return conn.Call(ctx, MethodClientUnregisterCapability, &UnregistrationParams{Unregisterations: unregistrations}, nil)
+}
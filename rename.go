@@ -0,0 +1,158 @@
+package golsptoolkit
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Methods of rename.
+const (
+	MethodTextDocumentRename        = "textDocument/rename"
+	MethodTextDocumentPrepareRename = "textDocument/prepareRename"
+)
+
+// PrepareSupportDefaultBehavior represents the default behavior a client
+// applies when a prepareRename request answers with defaultBehavior.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_rename
+type PrepareSupportDefaultBehavior UInteger
+
+const (
+	// PrepareSupportDefaultBehaviorIdentifier selects the identifier at the
+	// position according to the language's syntax rules.
+	PrepareSupportDefaultBehaviorIdentifier PrepareSupportDefaultBehavior = 1
+)
+
+var prepareSupportDefaultBehaviorNames = enumNames{"PrepareSupportDefaultBehavior", []string{
+	PrepareSupportDefaultBehaviorIdentifier: "Identifier",
+}}
+
+// String returns the name of b, such as "Identifier".
+func (b PrepareSupportDefaultBehavior) String() string {
+	return prepareSupportDefaultBehaviorNames.name(int64(b))
+}
+
+// IsValid reports whether b is one of the defined default behaviors.
+func (b PrepareSupportDefaultBehavior) IsValid() bool {
+	return prepareSupportDefaultBehaviorNames.valid(int64(b))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Undefined values are rejected.
+func (b *PrepareSupportDefaultBehavior) UnmarshalJSON(data []byte) error {
+	return decodeEnum(data, b, prepareSupportDefaultBehaviorNames)
+}
+
+// RenameOptions represents the rename options a server announces in
+// ServerCapabilities.RenameProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#renameOptions
+type RenameOptions struct {
+	WorkDoneProgressOptions
+
+	// PrepareProvider reports whether the server answers
+	// textDocument/prepareRename requests. It may only be set if the
+	// client announced prepareSupport.
+	PrepareProvider bool `json:"prepareProvider,omitempty"`
+}
+
+// RenameRegistrationOptions are the options of a dynamic registration of
+// rename.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#renameRegistrationOptions
+type RenameRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	RenameOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (RenameRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentRename
+}
+
+// RenameParams are the parameters of the textDocument/rename request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#renameParams
+type RenameParams struct {
+	TextDocumentPositionParams
+	WorkDoneProgressParams
+
+	// NewName is the new name of the symbol. If it is not valid, the
+	// request must fail with an error message.
+	NewName string `json:"newName"`
+}
+
+// PrepareRenameParams are the parameters of the textDocument/prepareRename
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#prepareRenameParams
+type PrepareRenameParams struct {
+	TextDocumentPositionParams
+	WorkDoneProgressParams
+}
+
+// PrepareRenameResult is the result of the textDocument/prepareRename
+// request. It takes one of three shapes: a range, a range with a
+// placeholder, or, if DefaultBehavior is set, a request for the client to
+// use its default behavior. A nil *PrepareRenameResult encodes as null,
+// meaning renaming is not valid at the position.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_prepareRename
+type PrepareRenameResult struct {
+	// Range is the range of the string to rename.
+	Range Range
+
+	// Placeholder is the text shown in the rename input box. If empty, the
+	// result is encoded as a plain range.
+	Placeholder string
+
+	// DefaultBehavior asks the client to determine the range itself,
+	// according to its prepareSupportDefaultBehavior. Range and
+	// Placeholder are then ignored.
+	DefaultBehavior bool
+}
+
+// prepareRenameResult is the wire form of the object shapes of
+// PrepareRenameResult.
+type prepareRenameResult struct {
+	Range           *Range `json:"range,omitempty"`
+	Placeholder     string `json:"placeholder,omitempty"`
+	DefaultBehavior *bool  `json:"defaultBehavior,omitempty"`
+}
+
+// errPrepareRenameResult is returned when a prepareRename result has none of
+// the defined shapes.
+var errPrepareRenameResult = errors.New("golsptoolkit: prepareRename result must be a range, a range with a placeholder, or an object with defaultBehavior")
+
+// MarshalJSON implements json.Marshaler.
+func (r PrepareRenameResult) MarshalJSON() ([]byte, error) {
+	switch {
+	case r.DefaultBehavior:
+		return json.Marshal(prepareRenameResult{DefaultBehavior: &r.DefaultBehavior})
+	case r.Placeholder != "":
+		return json.Marshal(prepareRenameResult{Range: &r.Range, Placeholder: r.Placeholder})
+	}
+	return json.Marshal(r.Range)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *PrepareRenameResult) UnmarshalJSON(data []byte) error {
+	var v struct {
+		prepareRenameResult
+		Start *Position `json:"start"`
+		End   *Position `json:"end"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch {
+	case v.DefaultBehavior != nil:
+		*r = PrepareRenameResult{DefaultBehavior: *v.DefaultBehavior}
+	case v.Range != nil:
+		*r = PrepareRenameResult{Range: *v.Range, Placeholder: v.Placeholder}
+	case v.Start != nil && v.End != nil:
+		*r = PrepareRenameResult{Range: Range{Start: *v.Start, End: *v.End}}
+	default:
+		return errPrepareRenameResult
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package golsptoolkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+)
+
+// Scheduler decides when and where incoming messages are handled.
+//
+// Schedule is called by JSONRPCConn on its dispatch goroutine, once per
+// incoming request or notification and in the order they arrive. It must
+// eventually call run exactly once and should not block, since no further
+// message is dispatched until it returns.
+type Scheduler interface {
+	Schedule(req *Request, run func())
+}
+
+// DocumentScheduler is a Scheduler that keeps messages about the same text
+// document in order while letting everything else run concurrently. LSP
+// requires changes to a document to be applied in the order they were sent,
+// so a didChange must not overtake an earlier didOpen, nor a completion
+// request the didChange it follows.
+//
+// Messages that name a document are queued per document and handled one at
+// a time in arrival order. Other requests are handled on their own
+// goroutine and other notifications inline, as JSONRPCConn does by default.
+//
+// The zero value is ready to use.
+type DocumentScheduler struct {
+	// Key returns the document req is about, or "" if it is about none. If
+	// nil, RequestDocumentURI is used.
+	Key func(req *Request) string
+
+	mu     sync.Mutex
+	queues map[string][]func()
+}
+
+// Schedule implements Scheduler.
+func (s *DocumentScheduler) Schedule(req *Request, run func()) {
+	key := s.key(req)
+	if key == "" {
+		if req.IsNotification() {
+			run()
+		} else {
+			go run()
+		}
+		return
+	}
+
+	s.mu.Lock()
+	if s.queues == nil {
+		s.queues = make(map[string][]func())
+	}
+	q, busy := s.queues[key]
+	s.queues[key] = append(q, run)
+	s.mu.Unlock()
+
+	if !busy {
+		go s.drain(key)
+	}
+}
+
+func (s *DocumentScheduler) key(req *Request) string {
+	if s.Key != nil {
+		return s.Key(req)
+	}
+	return RequestDocumentURI(req)
+}
+
+// drain runs the queued work for key until the queue is empty.
+func (s *DocumentScheduler) drain(key string) {
+	for {
+		s.mu.Lock()
+		q := s.queues[key]
+		if len(q) == 0 {
+			delete(s.queues, key)
+			s.mu.Unlock()
+			return
+		}
+		run := q[0]
+		q[0] = nil
+		s.queues[key] = q[1:]
+		s.mu.Unlock()
+
+		run()
+	}
+}
+
+// RequestDocumentURI returns the textDocument.uri member of req's params, or
+// "" if there is none. Decoding stops at the textDocument member, so the
+// rest of the params, such as the full text carried by a didChange, is
+// neither decoded nor copied if it comes later.
+func RequestDocumentURI(req *Request) string {
+	if len(req.Params) == 0 {
+		return ""
+	}
+	dec := json.NewDecoder(bytes.NewReader(req.Params))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return ""
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return ""
+		}
+		if key != "textDocument" {
+			if dec.Decode(new(skippedValue)) != nil {
+				return ""
+			}
+			continue
+		}
+		var doc struct {
+			URI string `json:"uri"`
+		}
+		if dec.Decode(&doc) != nil {
+			return ""
+		}
+		return doc.URI
+	}
+	return ""
+}
+
+// skippedValue consumes a JSON value without decoding it.
+type skippedValue struct{}
+
+func (*skippedValue) UnmarshalJSON([]byte) error { return nil }
@@ -0,0 +1,87 @@
+package golsptoolkit
+
+import "fmt"
+
+// Methods of selection ranges.
+const (
+	MethodTextDocumentSelectionRange = "textDocument/selectionRange"
+)
+
+// SelectionRangeOptions represents the selection range options a server
+// announces in ServerCapabilities.SelectionRangeProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#selectionRangeOptions
+type SelectionRangeOptions struct {
+	WorkDoneProgressOptions
+}
+
+// SelectionRangeRegistrationOptions are the options of a dynamic or static
+// registration of selection range.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#selectionRangeRegistrationOptions
+type SelectionRangeRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	SelectionRangeOptions
+	StaticRegistrationOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (SelectionRangeRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentSelectionRange
+}
+
+// SelectionRangeParams are the parameters of the
+// textDocument/selectionRange request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#selectionRangeParams
+type SelectionRangeParams struct {
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// TextDocument is the document.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+
+	// Positions are the positions to compute selection ranges for. The
+	// result holds one selection range per position, in order.
+	Positions []Position `json:"positions"`
+}
+
+// SelectionRange represents a range to select, such as an expression, and
+// its enclosing selection ranges, such as the statement and the function it
+// is part of.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#selectionRange
+type SelectionRange struct {
+	// Range is the range of the selection range.
+	Range Range `json:"range"`
+
+	// Parent is the enclosing selection range. Its range must contain
+	// Range.
+	Parent *SelectionRange `json:"parent,omitempty"`
+}
+
+// NewSelectionRange returns the selection range chain for ranges, ordered
+// from innermost to outermost: the result has the first range, whose parent
+// has the second, and so on. It returns nil if ranges is empty.
+func NewSelectionRange(ranges ...Range) *SelectionRange {
+	var s *SelectionRange
+	for i := len(ranges) - 1; i >= 0; i-- {
+		s = &SelectionRange{Range: ranges[i], Parent: s}
+	}
+	return s
+}
+
+// Validate reports an error if the range of a selection range in the chain
+// is not contained in the range of its parent, or ends before it starts.
+func (s *SelectionRange) Validate() error {
+	for depth := 0; s != nil; depth++ {
+		if s.Range.End.Before(s.Range.Start) {
+			return fmt.Errorf("golsptoolkit: selection range at depth %d ends before it starts", depth)
+		}
+		if s.Parent != nil && !s.Parent.Range.ContainsRange(s.Range) {
+			return fmt.Errorf("golsptoolkit: selection range at depth %d is not contained in its parent", depth)
+		}
+		s = s.Parent
+	}
+	return nil
+}
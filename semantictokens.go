@@ -0,0 +1,260 @@
+package golsptoolkit
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Methods of semantic tokens.
+const (
+	// MethodTextDocumentSemanticTokens is the method under which all
+	// semantic token requests are registered dynamically.
+	MethodTextDocumentSemanticTokens = "textDocument/semanticTokens"
+
+	MethodTextDocumentSemanticTokensFull      = "textDocument/semanticTokens/full"
+	MethodTextDocumentSemanticTokensFullDelta = "textDocument/semanticTokens/full/delta"
+	MethodTextDocumentSemanticTokensRange     = "textDocument/semanticTokens/range"
+	MethodWorkspaceSemanticTokensRefresh      = "workspace/semanticTokens/refresh"
+)
+
+// SemanticTokenType represents a predefined semantic token type. Servers may
+// use other types, as long as their legend lists them.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#semanticTokenTypes
+type SemanticTokenType = string
+
+const (
+	SemanticTokenTypeNamespace     SemanticTokenType = "namespace"
+	SemanticTokenTypeType          SemanticTokenType = "type"
+	SemanticTokenTypeClass         SemanticTokenType = "class"
+	SemanticTokenTypeEnum          SemanticTokenType = "enum"
+	SemanticTokenTypeInterface     SemanticTokenType = "interface"
+	SemanticTokenTypeStruct        SemanticTokenType = "struct"
+	SemanticTokenTypeTypeParameter SemanticTokenType = "typeParameter"
+	SemanticTokenTypeParameter     SemanticTokenType = "parameter"
+	SemanticTokenTypeVariable      SemanticTokenType = "variable"
+	SemanticTokenTypeProperty      SemanticTokenType = "property"
+	SemanticTokenTypeEnumMember    SemanticTokenType = "enumMember"
+	SemanticTokenTypeEvent         SemanticTokenType = "event"
+	SemanticTokenTypeFunction      SemanticTokenType = "function"
+	SemanticTokenTypeMethod        SemanticTokenType = "method"
+	SemanticTokenTypeMacro         SemanticTokenType = "macro"
+	SemanticTokenTypeKeyword       SemanticTokenType = "keyword"
+	SemanticTokenTypeModifier      SemanticTokenType = "modifier"
+	SemanticTokenTypeComment       SemanticTokenType = "comment"
+	SemanticTokenTypeString        SemanticTokenType = "string"
+	SemanticTokenTypeNumber        SemanticTokenType = "number"
+	SemanticTokenTypeRegexp        SemanticTokenType = "regexp"
+	SemanticTokenTypeOperator      SemanticTokenType = "operator"
+	SemanticTokenTypeDecorator     SemanticTokenType = "decorator"
+)
+
+// SemanticTokenModifier represents a predefined semantic token modifier.
+// Servers may use other modifiers, as long as their legend lists them.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#semanticTokenModifiers
+type SemanticTokenModifier = string
+
+const (
+	SemanticTokenModifierDeclaration    SemanticTokenModifier = "declaration"
+	SemanticTokenModifierDefinition     SemanticTokenModifier = "definition"
+	SemanticTokenModifierReadonly       SemanticTokenModifier = "readonly"
+	SemanticTokenModifierStatic         SemanticTokenModifier = "static"
+	SemanticTokenModifierDeprecated     SemanticTokenModifier = "deprecated"
+	SemanticTokenModifierAbstract       SemanticTokenModifier = "abstract"
+	SemanticTokenModifierAsync          SemanticTokenModifier = "async"
+	SemanticTokenModifierModification   SemanticTokenModifier = "modification"
+	SemanticTokenModifierDocumentation  SemanticTokenModifier = "documentation"
+	SemanticTokenModifierDefaultLibrary SemanticTokenModifier = "defaultLibrary"
+)
+
+// TokenFormat represents the encoding of semantic tokens.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_semanticTokens
+type TokenFormat = string
+
+const (
+	TokenFormatRelative TokenFormat = "relative"
+)
+
+// SemanticTokensLegend represents the token types and modifiers a server
+// uses. Tokens refer to types by index and to modifiers by bit.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#semanticTokensLegend
+type SemanticTokensLegend struct {
+	// TokenTypes are the token types, indexed by the token type of a
+	// token.
+	TokenTypes []string `json:"tokenTypes"`
+
+	// TokenModifiers are the token modifiers; bit i of the modifiers of a
+	// token sets TokenModifiers[i].
+	TokenModifiers []string `json:"tokenModifiers"`
+}
+
+// SemanticTokensOptions represents the semantic tokens options a server
+// announces in ServerCapabilities.SemanticTokensProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#semanticTokensOptions
+type SemanticTokensOptions struct {
+	WorkDoneProgressOptions
+
+	// Legend is the legend the server uses.
+	Legend SemanticTokensLegend `json:"legend"`
+
+	// Range is true, or an empty object, if the server answers
+	// textDocument/semanticTokens/range requests.
+	Range Or2[bool, struct{}] `json:"range,omitzero"`
+
+	// Full is true, or a SemanticTokensFullOptions, if the server answers
+	// textDocument/semanticTokens/full requests.
+	Full Or2[bool, SemanticTokensFullOptions] `json:"full,omitzero"`
+}
+
+// SemanticTokensFullOptions describes the server's support for full
+// semantic tokens requests.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#semanticTokensOptions
+type SemanticTokensFullOptions struct {
+	// Delta reports whether the server answers
+	// textDocument/semanticTokens/full/delta requests.
+	Delta bool `json:"delta,omitempty"`
+}
+
+// SemanticTokensRegistrationOptions are the options of a dynamic or static
+// registration of semantic tokens.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#semanticTokensRegistrationOptions
+type SemanticTokensRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	SemanticTokensOptions
+	StaticRegistrationOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (SemanticTokensRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentSemanticTokens
+}
+
+// SemanticTokensParams are the parameters of the
+// textDocument/semanticTokens/full request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#semanticTokensParams
+type SemanticTokensParams struct {
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// TextDocument is the document.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+// SemanticTokensDeltaParams are the parameters of the
+// textDocument/semanticTokens/full/delta request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#semanticTokensDeltaParams
+type SemanticTokensDeltaParams struct {
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// TextDocument is the document.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+
+	// PreviousResultID is the result id of the tokens the delta is
+	// relative to.
+	PreviousResultID string `json:"previousResultId"`
+}
+
+// SemanticTokensRangeParams are the parameters of the
+// textDocument/semanticTokens/range request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#semanticTokensRangeParams
+type SemanticTokensRangeParams struct {
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// TextDocument is the document.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+
+	// Range is the range to compute tokens for.
+	Range Range `json:"range"`
+}
+
+// SemanticTokens represents the semantic tokens of a document or a range.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#semanticTokens
+type SemanticTokens struct {
+	// ResultID identifies the tokens, for use as the PreviousResultID of
+	// a delta request.
+	ResultID string `json:"resultId,omitempty"`
+
+	// Data holds five integers per token: the line, relative to the
+	// previous token; the start character, relative to the previous token
+	// if on the same line; the length; the token type; and the token
+	// modifiers.
+	Data []UInteger `json:"data"`
+}
+
+// MarshalJSON implements json.Marshaler. A nil Data is sent as an empty
+// array.
+func (t SemanticTokens) MarshalJSON() ([]byte, error) {
+	type tokens SemanticTokens
+	if t.Data == nil {
+		t.Data = []UInteger{}
+	}
+	return json.Marshal(tokens(t))
+}
+
+// SemanticTokensPartialResult is a partial result of a semantic tokens
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#semanticTokensPartialResult
+type SemanticTokensPartialResult struct {
+	Data []UInteger `json:"data"`
+}
+
+// SemanticTokensDelta is a result of the
+// textDocument/semanticTokens/full/delta request, which may also answer
+// with full SemanticTokens.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#semanticTokensDelta
+type SemanticTokensDelta struct {
+	// ResultID identifies the tokens after the edits.
+	ResultID string `json:"resultId,omitempty"`
+
+	// Edits are the edits to apply to the previous tokens' data.
+	Edits []SemanticTokensEdit `json:"edits"`
+}
+
+// SemanticTokensDeltaPartialResult is a partial result of the
+// textDocument/semanticTokens/full/delta request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#semanticTokensDeltaPartialResult
+type SemanticTokensDeltaPartialResult struct {
+	Edits []SemanticTokensEdit `json:"edits"`
+}
+
+// SemanticTokensEdit represents an edit to the data of semantic tokens.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#semanticTokensEdit
+type SemanticTokensEdit struct {
+	// Start is the index in the data to replace at.
+	Start UInteger `json:"start"`
+
+	// DeleteCount is the number of integers to remove.
+	DeleteCount UInteger `json:"deleteCount"`
+
+	// Data are the integers to insert.
+	Data []UInteger `json:"data,omitempty"`
+}
+
+// RefreshSemanticTokens asks the client to refresh the semantic tokens of
+// all documents with the workspace/semanticTokens/refresh request, for
+// example after a project-wide change. It does nothing if caps, the
+// client's capabilities, do not announce refreshSupport.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#semanticTokens_refreshRequest
+func RefreshSemanticTokens(ctx context.Context, conn *JSONRPCConn, caps *ClientCapabilities) error {
+	if caps == nil || caps.Workspace == nil || caps.Workspace.SemanticTokens == nil || !caps.Workspace.SemanticTokens.RefreshSupport {
+		return nil
+	}
+	return conn.Call(ctx, MethodWorkspaceSemanticTokensRefresh, nil, nil)
+}
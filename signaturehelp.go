@@ -0,0 +1,171 @@
+package golsptoolkit
+
+// Methods of signature help.
+const (
+	MethodTextDocumentSignatureHelp = "textDocument/signatureHelp"
+)
+
+// SignatureHelpOptions represents the signature help options a server
+// announces in ServerCapabilities.SignatureHelpProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#signatureHelpOptions
+type SignatureHelpOptions struct {
+	WorkDoneProgressOptions
+
+	// TriggerCharacters trigger signature help automatically when typed.
+	TriggerCharacters []string `json:"triggerCharacters,omitempty"`
+
+	// RetriggerCharacters re-trigger signature help when typed while it is
+	// shown. TriggerCharacters re-trigger it as well.
+	RetriggerCharacters []string `json:"retriggerCharacters,omitempty"`
+}
+
+// SignatureHelpRegistrationOptions are the options of a dynamic registration of
+// signature help.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#signatureHelpRegistrationOptions
+type SignatureHelpRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	SignatureHelpOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (SignatureHelpRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentSignatureHelp
+}
+
+// SignatureHelpParams are the parameters of the textDocument/signatureHelp
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#signatureHelpParams
+type SignatureHelpParams struct {
+	TextDocumentPositionParams
+	WorkDoneProgressParams
+
+	// Context describes how signature help was triggered. It is only set
+	// if the client announced contextSupport.
+	Context *SignatureHelpContext `json:"context,omitempty"`
+}
+
+// SignatureHelpTriggerKind represents how signature help was triggered.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#signatureHelpTriggerKind
+type SignatureHelpTriggerKind UInteger
+
+const (
+	// SignatureHelpTriggerKindInvoked is signature help invoked manually
+	// by the user or by a command.
+	SignatureHelpTriggerKindInvoked SignatureHelpTriggerKind = 1
+
+	// SignatureHelpTriggerKindTriggerCharacter is signature help triggered
+	// by a trigger character.
+	SignatureHelpTriggerKindTriggerCharacter SignatureHelpTriggerKind = 2
+
+	// SignatureHelpTriggerKindContentChange is signature help triggered by
+	// the cursor moving or the document changing.
+	SignatureHelpTriggerKindContentChange SignatureHelpTriggerKind = 3
+)
+
+var signatureHelpTriggerKindNames = enumNames{"SignatureHelpTriggerKind", []string{
+	SignatureHelpTriggerKindInvoked:          "Invoked",
+	SignatureHelpTriggerKindTriggerCharacter: "TriggerCharacter",
+	SignatureHelpTriggerKindContentChange:    "ContentChange",
+}}
+
+// String returns the name of k, such as "Invoked".
+func (k SignatureHelpTriggerKind) String() string {
+	return signatureHelpTriggerKindNames.name(int64(k))
+}
+
+// IsValid reports whether k is one of the defined signature help trigger kinds.
+func (k SignatureHelpTriggerKind) IsValid() bool {
+	return signatureHelpTriggerKindNames.valid(int64(k))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Undefined values are rejected.
+func (k *SignatureHelpTriggerKind) UnmarshalJSON(data []byte) error {
+	return decodeEnum(data, k, signatureHelpTriggerKindNames)
+}
+
+// SignatureHelpContext describes how signature help was triggered.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#signatureHelpContext
+type SignatureHelpContext struct {
+	TriggerKind SignatureHelpTriggerKind `json:"triggerKind"`
+
+	// TriggerCharacter is the character that triggered signature help, if
+	// TriggerKind is SignatureHelpTriggerKindTriggerCharacter.
+	TriggerCharacter string `json:"triggerCharacter,omitempty"`
+
+	// IsRetrigger reports whether signature help was already showing.
+	IsRetrigger bool `json:"isRetrigger"`
+
+	// ActiveSignatureHelp is the signature help currently showing, with
+	// ActiveSignature updated to the signature the user selected.
+	ActiveSignatureHelp *SignatureHelp `json:"activeSignatureHelp,omitempty"`
+}
+
+// SignatureHelp is the result of the textDocument/signatureHelp request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#signatureHelp
+type SignatureHelp struct {
+	// Signatures are one or more signatures.
+	Signatures []SignatureInformation `json:"signatures"`
+
+	// ActiveSignature is the index of the active signature. If nil or out
+	// of range, it is 0.
+	ActiveSignature *UInteger `json:"activeSignature,omitempty"`
+
+	// ActiveParameter is the index of the active parameter of the active
+	// signature. SignatureInformation.ActiveParameter takes precedence.
+	ActiveParameter *UInteger `json:"activeParameter,omitempty"`
+}
+
+// SignatureInformation represents the signature of something callable.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#signatureInformation
+type SignatureInformation struct {
+	// Label is shown in the user interface.
+	Label string `json:"label"`
+
+	// Documentation is a doc comment for the signature.
+	Documentation Or2[string, MarkupContent] `json:"documentation,omitzero"`
+
+	// Parameters are the parameters of the signature.
+	Parameters []ParameterInformation `json:"parameters,omitempty"`
+
+	// ActiveParameter is the index of the active parameter. If set, it is
+	// used instead of SignatureHelp.ActiveParameter.
+	ActiveParameter *UInteger `json:"activeParameter,omitempty"`
+}
+
+// ParameterInformation represents a parameter of a signature.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#parameterInformation
+type ParameterInformation struct {
+	// Label identifies the parameter in the signature label.
+	Label ParameterLabel `json:"label"`
+
+	// Documentation is a doc comment for the parameter.
+	Documentation Or2[string, MarkupContent] `json:"documentation,omitzero"`
+}
+
+// ParameterLabel identifies a parameter in the label of its signature:
+// either a substring of the label, or a [start, end) range of offsets into
+// it, counted in the negotiated position encoding. Offsets need the
+// client's labelOffsetSupport capability.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#parameterInformation
+type ParameterLabel = Or2[string, [2]UInteger]
+
+// NewParameterLabel returns a parameter label given as a substring of the
+// signature label.
+func NewParameterLabel(label string) ParameterLabel {
+	return NewOr2A[string, [2]UInteger](label)
+}
+
+// NewParameterLabelOffsets returns a parameter label given as the offsets
+// start and end into the signature label.
+func NewParameterLabelOffsets(start, end UInteger) ParameterLabel {
+	return NewOr2B[string]([2]UInteger{start, end})
+}
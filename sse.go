@@ -0,0 +1,355 @@
+package golsptoolkit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// sseSessionParam is the query parameter identifying the session a POSTed
+// message belongs to.
+const sseSessionParam = "session"
+
+// SSE event names used by the HTTP transport.
+const (
+	sseEndpointEvent = "endpoint"
+	sseMessageEvent  = "message"
+)
+
+// DefaultMaxSSEMessageSize is the default bound, in bytes, on a message
+// POSTed to an SSEServer.
+const DefaultMaxSSEMessageSize = 64 << 20
+
+// SSEServer serves LSP sessions over plain HTTP for web IDEs behind proxies
+// that block WebSockets. A client opens a session with a GET request, which
+// becomes a Server-Sent Events stream; the first event, named "endpoint",
+// carries the URL to which the client POSTs its messages, one JSON-RPC
+// message per request. Server-to-client messages arrive as "message" events.
+type SSEServer struct {
+	// Handler is called in its own goroutine for each new session and
+	// should block until the session ends.
+	Handler func(conn Conn)
+
+	// MaxMessageSize is the largest POSTed message accepted; larger ones
+	// are answered with 413 Request Entity Too Large. Zero means
+	// DefaultMaxSSEMessageSize.
+	MaxMessageSize int64
+
+	mu       sync.Mutex
+	sessions map[string]*sseSession
+}
+
+// ServeHTTP implements http.Handler.
+func (s *SSEServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.serveEvents(w, r)
+	case http.MethodPost:
+		s.serveMessage(w, r)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *SSEServer) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	var raw [16]byte
+	if _, err := rand.Read(raw[:]); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	id := hex.EncodeToString(raw[:])
+	sess := &sseSession{
+		inbox: newInbox(),
+		out:   make(chan []byte),
+		done:  make(chan struct{}),
+	}
+
+	s.mu.Lock()
+	if s.sessions == nil {
+		s.sessions = make(map[string]*sseSession)
+	}
+	s.sessions[id] = sess
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.sessions, id)
+		s.mu.Unlock()
+		sess.Close()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	endpoint := *r.URL
+	q := endpoint.Query()
+	q.Set(sseSessionParam, id)
+	endpoint.RawQuery = q.Encode()
+	if err := writeSSEEvent(w, sseEndpointEvent, []byte(endpoint.RequestURI())); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	go s.Handler(sess)
+
+	for {
+		select {
+		case body := <-sess.out:
+			if err := writeSSEEvent(w, sseMessageEvent, body); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-sess.done:
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func (s *SSEServer) serveMessage(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	sess := s.sessions[r.URL.Query().Get(sseSessionParam)]
+	s.mu.Unlock()
+	if sess == nil {
+		http.Error(w, "unknown session", http.StatusNotFound)
+		return
+	}
+
+	limit := s.MaxMessageSize
+	if limit <= 0 {
+		limit = DefaultMaxSSEMessageSize
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	sess.push(body)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// writeSSEEvent writes one event, splitting data over several data lines if
+// it contains line breaks.
+func writeSSEEvent(w io.Writer, event string, data []byte) error {
+	var buf bytes.Buffer
+	buf.WriteString("event: ")
+	buf.WriteString(event)
+	buf.WriteByte('\n')
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		buf.WriteString("data: ")
+		buf.Write(bytes.TrimSuffix(line, []byte("\r")))
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// sseSession is the server side of one SSE session.
+type sseSession struct {
+	*inbox
+	out       chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (s *sseSession) WriteMessage(ctx context.Context, body []byte) error {
+	select {
+	case s.out <- body:
+		return nil
+	case <-s.done:
+		return net.ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *sseSession) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.close(io.EOF)
+	})
+	return nil
+}
+
+// DialSSE opens a session with the SSEServer at rawURL. A nil client uses
+// http.DefaultClient.
+func DialSSE(ctx context.Context, rawURL string, client *http.Client) (Conn, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	base, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	sctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(sctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	type dialResult struct {
+		resp *http.Response
+		err  error
+	}
+	ch := make(chan dialResult, 1)
+	go func() {
+		resp, err := client.Do(req)
+		ch <- dialResult{resp, err}
+	}()
+
+	var resp *http.Response
+	select {
+	case res := <-ch:
+		if res.err != nil {
+			cancel()
+			return nil, res.err
+		}
+		resp = res.resp
+	case <-ctx.Done():
+		cancel()
+		return nil, ctx.Err()
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("golsptoolkit: sse connect failed: %s", resp.Status)
+	}
+
+	c := &sseClientConn{
+		client: client,
+		events: bufio.NewReader(resp.Body),
+		body:   resp.Body,
+		cancel: cancel,
+	}
+	c.reader.read = c.readMessage
+
+	event, data, err := c.readEvent()
+	if err == nil && event != sseEndpointEvent {
+		err = fmt.Errorf("golsptoolkit: sse stream started with %q event", event)
+	}
+	var endpoint *url.URL
+	if err == nil {
+		endpoint, err = base.Parse(string(data))
+	}
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+	c.endpoint = endpoint.String()
+	return c, nil
+}
+
+// sseClientConn is the client side of an SSE session.
+type sseClientConn struct {
+	client   *http.Client
+	endpoint string
+	events   *bufio.Reader
+	body     io.Closer
+	cancel   context.CancelFunc
+	reader   contextReader
+}
+
+func (c *sseClientConn) ReadMessage(ctx context.Context) ([]byte, error) {
+	return c.reader.ReadMessage(ctx)
+}
+
+func (c *sseClientConn) readMessage() ([]byte, error) {
+	for {
+		event, data, err := c.readEvent()
+		if err != nil {
+			return nil, err
+		}
+		if event == sseMessageEvent {
+			return data, nil
+		}
+	}
+}
+
+// readEvent reads the next event from the stream. Comments and fields other
+// than event and data are ignored.
+func (c *sseClientConn) readEvent() (event string, data []byte, err error) {
+	var lines [][]byte
+	for {
+		line, err := c.events.ReadBytes('\n')
+		if err != nil {
+			if err == io.EOF && len(lines) > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+			return "", nil, err
+		}
+		line = bytes.TrimRight(line, "\r\n")
+
+		if len(line) == 0 {
+			if event == "" && lines == nil {
+				continue
+			}
+			if event == "" {
+				event = sseMessageEvent
+			}
+			return event, bytes.Join(lines, []byte("\n")), nil
+		}
+
+		field, value, _ := bytes.Cut(line, []byte(":"))
+		value = bytes.TrimPrefix(value, []byte(" "))
+		switch string(field) {
+		case "event":
+			event = string(value)
+		case "data":
+			lines = append(lines, value)
+		}
+	}
+}
+
+func (c *sseClientConn) WriteMessage(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("golsptoolkit: sse post failed: %s", resp.Status)
+	}
+	return nil
+}
+
+func (c *sseClientConn) Close() error {
+	c.cancel()
+	err := c.body.Close()
+	if errors.Is(err, context.Canceled) {
+		err = nil
+	}
+	return err
+}
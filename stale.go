@@ -0,0 +1,130 @@
+package golsptoolkit
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// StaleHandler is a Handler that abandons requests about a document once
+// the document changes, as mature servers such as gopls do. When a
+// textDocument/didChange passes through, every request about that document
+// still being handled is answered with ContentModified and its context is
+// canceled; the client will ask again against the new contents. The late
+// reply of the abandoned handler is discarded with ErrAlreadyReplied.
+//
+// Requests are matched to documents with RequestDocumentURI.
+//
+// A Scheduler that orders messages per document, such as DocumentScheduler,
+// holds a didChange back until the requests before it are answered, so they
+// would never be abandoned. Wrap it with the StaleHandler's Scheduler method
+// to abandon them as soon as the didChange arrives.
+type StaleHandler struct {
+	// Handler handles the messages.
+	Handler Handler
+
+	// Exempt lists methods whose requests are never abandoned, such as
+	// those whose results stay valid across edits.
+	Exempt map[string]bool
+
+	mu      sync.Mutex
+	running map[string]map[*staleRequest]struct{}
+}
+
+// staleRequest is a request about a document that has not been answered.
+type staleRequest struct {
+	ctx   context.Context
+	reply Replier
+}
+
+// Handle implements Handler.
+func (h *StaleHandler) Handle(ctx context.Context, reply Replier, req *Request) {
+	uri := RequestDocumentURI(req)
+	if req.Method == MethodTextDocumentDidChange && uri != "" {
+		h.abandon(uri)
+	}
+	if req.IsNotification() || uri == "" || h.Exempt[req.Method] {
+		h.Handler.Handle(ctx, reply, req)
+		return
+	}
+
+	r := &staleRequest{ctx: ctx}
+	ctx, cancel := context.WithCancel(ctx)
+	var replied atomic.Bool
+	r.reply = func(ctx context.Context, result any, err error) error {
+		if !replied.CompareAndSwap(false, true) {
+			return ErrAlreadyReplied
+		}
+		h.forget(uri, r)
+		defer cancel()
+		return reply(ctx, result, err)
+	}
+
+	h.mu.Lock()
+	if h.running == nil {
+		h.running = make(map[string]map[*staleRequest]struct{})
+	}
+	if h.running[uri] == nil {
+		h.running[uri] = make(map[*staleRequest]struct{})
+	}
+	h.running[uri][r] = struct{}{}
+	h.mu.Unlock()
+
+	h.Handler.Handle(ctx, r.reply, req)
+
+	// A detached request stays subject to abandonment until it is answered.
+	if st, ok := ctx.Value(requestStateKey{}).(*requestState); !ok || !st.detached.Load() {
+		h.forget(uri, r)
+		cancel()
+	}
+}
+
+// abandon answers every request running against uri with ContentModified.
+func (h *StaleHandler) abandon(uri string) {
+	h.mu.Lock()
+	running := h.running[uri]
+	delete(h.running, uri)
+	h.mu.Unlock()
+
+	for r := range running {
+		r.reply(r.ctx, nil, NewError(ContentModified, "document changed: "+uri))
+	}
+}
+
+// Scheduler returns a Scheduler that abandons the requests made stale by a
+// didChange as soon as the didChange is scheduled, and then schedules it,
+// like every other message, with next. If next is nil, messages are
+// handled as JSONRPCConn does by default.
+func (h *StaleHandler) Scheduler(next Scheduler) Scheduler {
+	return &staleScheduler{h: h, next: next}
+}
+
+type staleScheduler struct {
+	h    *StaleHandler
+	next Scheduler
+}
+
+func (s *staleScheduler) Schedule(req *Request, run func()) {
+	if req.Method == MethodTextDocumentDidChange {
+		if uri := RequestDocumentURI(req); uri != "" {
+			s.h.abandon(uri)
+		}
+	}
+	switch {
+	case s.next != nil:
+		s.next.Schedule(req, run)
+	case req.IsNotification():
+		run()
+	default:
+		go run()
+	}
+}
+
+func (h *StaleHandler) forget(uri string, r *staleRequest) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.running[uri], r)
+	if len(h.running[uri]) == 0 {
+		delete(h.running, uri)
+	}
+}
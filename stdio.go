@@ -0,0 +1,22 @@
+package golsptoolkit
+
+import (
+	"errors"
+	"os"
+)
+
+// NewStdioConn returns a StreamConn that reads messages from os.Stdin and
+// writes them to os.Stdout, the default transport used by editors that spawn
+// a language server as a child process.
+//
+// Once the connection is in use nothing else may write to os.Stdout, or the
+// client will see corrupted frames; send logs to os.Stderr instead.
+func NewStdioConn() *StreamConn {
+	return NewStreamConn(os.Stdin, os.Stdout, stdioCloser{})
+}
+
+type stdioCloser struct{}
+
+func (stdioCloser) Close() error {
+	return errors.Join(os.Stdin.Close(), os.Stdout.Close())
+}
@@ -0,0 +1,90 @@
+package golsptoolkit
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+)
+
+// StreamConn carries framed LSP messages over a pair of byte streams.
+//
+// Reads must not be issued concurrently; writes may be, and are serialized so
+// that frames never interleave.
+type StreamConn struct {
+	r      *MessageReader
+	w      *MessageWriter
+	closer io.Closer
+
+	reader   contextReader
+	deadline writeDeadliner
+	wmu      sync.Mutex
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// NewStreamConn returns a StreamConn reading messages from r and writing
+// messages to w. If closer is non-nil it is closed by Close.
+func NewStreamConn(r io.Reader, w io.Writer, closer io.Closer) *StreamConn {
+	c := &StreamConn{
+		r:      NewMessageReader(r),
+		w:      NewMessageWriter(w),
+		closer: closer,
+	}
+	c.reader.read = c.r.ReadMessage
+	c.deadline, _ = w.(writeDeadliner)
+	return c
+}
+
+// Reader returns the MessageReader used for incoming messages, so that its
+// framing options can be configured before the connection is used.
+func (c *StreamConn) Reader() *MessageReader {
+	return c.r
+}
+
+// Writer returns the MessageWriter used for outgoing messages.
+func (c *StreamConn) Writer() *MessageWriter {
+	return c.w
+}
+
+// ReadMessage reads the content part of the next message.
+func (c *StreamConn) ReadMessage(ctx context.Context) ([]byte, error) {
+	return c.reader.ReadMessage(ctx)
+}
+
+// WriteMessage writes body as a single framed message. When the underlying
+// writer supports write deadlines, the deadline and cancellation of ctx
+// interrupt a blocked write.
+func (c *StreamConn) WriteMessage(ctx context.Context, body []byte) error {
+	if c.deadline == nil {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return c.w.WriteMessage(body)
+	}
+
+	// Deadlines apply to the whole stream, so writes are serialized here
+	// rather than only inside the MessageWriter.
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	return withWriteDeadline(ctx, c.deadline, func() error {
+		return c.w.WriteMessage(body)
+	})
+}
+
+// Close closes the underlying streams. It is safe to call more than once.
+func (c *StreamConn) Close() error {
+	c.closeOnce.Do(func() {
+		if c.closer != nil {
+			c.closeErr = c.closer.Close()
+		}
+	})
+	return c.closeErr
+}
+
+// NewNetConn returns a StreamConn carrying messages over the network
+// connection nc.
+func NewNetConn(nc net.Conn) *StreamConn {
+	return NewStreamConn(nc, nc, nc)
+}
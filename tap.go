@@ -0,0 +1,56 @@
+package golsptoolkit
+
+import (
+	"context"
+	"log"
+)
+
+// Tap observes the raw messages flowing through a Conn, for logging,
+// mirroring or analyzing traffic without touching any handler.
+//
+// Hooks are called synchronously with the content part of each message. They
+// must not modify body, and must copy it if they retain it.
+type Tap struct {
+	// OnReceive is called with every message read, before it is returned
+	// to the dispatcher.
+	OnReceive func(body []byte)
+
+	// OnSend is called with every message just before it is written. It may
+	// be called concurrently when several goroutines write.
+	OnSend func(body []byte)
+}
+
+// NewLogTap returns a Tap that prints every message to l, prefixed with
+// "<-" for incoming and "->" for outgoing traffic.
+func NewLogTap(l *log.Logger) Tap {
+	return Tap{
+		OnReceive: func(body []byte) { l.Printf("<- %s", body) },
+		OnSend:    func(body []byte) { l.Printf("-> %s", body) },
+	}
+}
+
+// TapConn returns a Conn that passes every message read from or written to c
+// through the hooks of t.
+func TapConn(c Conn, t Tap) Conn {
+	return &tapConn{Conn: c, tap: t}
+}
+
+type tapConn struct {
+	Conn
+	tap Tap
+}
+
+func (c *tapConn) ReadMessage(ctx context.Context) ([]byte, error) {
+	body, err := c.Conn.ReadMessage(ctx)
+	if err == nil && c.tap.OnReceive != nil {
+		c.tap.OnReceive(body)
+	}
+	return body, err
+}
+
+func (c *tapConn) WriteMessage(ctx context.Context, body []byte) error {
+	if c.tap.OnSend != nil {
+		c.tap.OnSend(body)
+	}
+	return c.Conn.WriteMessage(ctx, body)
+}
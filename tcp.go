@@ -0,0 +1,111 @@
+package golsptoolkit
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// SocketServer accepts LSP sessions on a stream listener.
+//
+// Connections are served sequentially: the next connection is accepted only
+// after Handler returns for the previous one, mirroring how an editor
+// restarts a socket-mode server.
+type SocketServer struct {
+	// Handler is called for each accepted connection and should block until
+	// the session ends. The connection is closed when Handler returns.
+	Handler func(conn Conn)
+
+	// Single makes Serve return after the first session ends.
+	Single bool
+
+	// TLSConfig, if non-nil, makes the server accept TLS connections only.
+	TLSConfig *tls.Config
+}
+
+// Serve accepts connections on l and serves them until l is closed or, when
+// Single is set, until the first session ends. The listener is closed when
+// Serve returns.
+func (s *SocketServer) Serve(l net.Listener) error {
+	if s.TLSConfig != nil {
+		l = tls.NewListener(l, s.TLSConfig)
+	}
+	defer l.Close()
+	for {
+		nc, err := l.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+
+		conn := NewNetConn(nc)
+		s.Handler(conn)
+		conn.Close()
+
+		if s.Single {
+			return nil
+		}
+	}
+}
+
+// ListenAndServe listens on the TCP address addr and serves connections with
+// s.Serve.
+func (s *SocketServer) ListenAndServe(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(l)
+}
+
+// ListenAndServe listens on the TCP address addr and calls handler for each
+// connection, one session at a time.
+func ListenAndServe(addr string, handler func(conn Conn)) error {
+	s := &SocketServer{Handler: handler}
+	return s.ListenAndServe(addr)
+}
+
+// DialTCP connects to the LSP peer listening on the TCP address addr.
+func DialTCP(ctx context.Context, addr string) (*StreamConn, error) {
+	var d net.Dialer
+	nc, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return NewNetConn(nc), nil
+}
+
+// ConnectSocket connects to a client listening on the loopback port, as
+// requested by the "--socket=<port>" launch argument used by VS Code.
+func ConnectSocket(ctx context.Context, port int) (*StreamConn, error) {
+	return DialTCP(ctx, net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+}
+
+// PortFromArgs looks for a "--socket" or "--port" argument in args, accepting
+// both the "--socket=N" and "--socket N" forms. It returns the flag name
+// without dashes, the port, and whether a valid port was found.
+func PortFromArgs(args []string) (flag string, port int, ok bool) {
+	for i, arg := range args {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "--") || (name != "socket" && name != "port") {
+			continue
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return "", 0, false
+			}
+			value = args[i+1]
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 || n > 65535 {
+			return "", 0, false
+		}
+		return name, n, true
+	}
+	return "", 0, false
+}
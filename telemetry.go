@@ -0,0 +1,37 @@
+package golsptoolkit
+
+import "context"
+
+// Methods of telemetry.
+const (
+	MethodTelemetryEvent = "telemetry/event"
+)
+
+// TelemetrySink receives the telemetry events emitted by feature code. It
+// hides whether the events are sent to the client, collected elsewhere, or
+// discarded.
+type TelemetrySink interface {
+	// TelemetryEvent emits event, which should encode as a JSON object or
+	// array.
+	TelemetryEvent(ctx context.Context, event LSPAny) error
+}
+
+// TelemetrySinkFunc adapts an ordinary function to a TelemetrySink.
+type TelemetrySinkFunc func(ctx context.Context, event LSPAny) error
+
+// TelemetryEvent calls f(ctx, event).
+func (f TelemetrySinkFunc) TelemetryEvent(ctx context.Context, event LSPAny) error {
+	return f(ctx, event)
+}
+
+// DiscardTelemetry is a TelemetrySink that drops all events, for clients
+// or users that opted out of telemetry.
+var DiscardTelemetry TelemetrySink = TelemetrySinkFunc(func(context.Context, LSPAny) error { return nil })
+
+// TelemetryEvent sends event to the client with the telemetry/event
+// notification. It makes JSONRPCConn a TelemetrySink.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#telemetry_event
+func (c *JSONRPCConn) TelemetryEvent(ctx context.Context, event LSPAny) error {
+	return c.Notify(ctx, MethodTelemetryEvent, event)
+}
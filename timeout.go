@@ -0,0 +1,62 @@
+package golsptoolkit
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// TimeoutHandler is a Handler that bounds how long requests may take, for
+// example 500ms for completion but 10s for workspace/symbol.
+//
+// The context passed to Handler carries the deadline. If the deadline passes
+// before Handler replies, the request is answered with RequestFailed and the
+// late reply is discarded with ErrAlreadyReplied. Notifications are passed
+// through without a deadline.
+type TimeoutHandler struct {
+	// Handler handles the requests.
+	Handler Handler
+
+	// Default is the deadline for methods not listed in Methods. Zero means
+	// no limit.
+	Default time.Duration
+
+	// Methods holds per-method deadlines, overriding Default. A zero entry
+	// exempts the method.
+	Methods map[string]time.Duration
+}
+
+// Handle implements Handler.
+func (h *TimeoutHandler) Handle(ctx context.Context, reply Replier, req *Request) {
+	d, ok := h.Methods[req.Method]
+	if !ok {
+		d = h.Default
+	}
+	if req.IsNotification() || d <= 0 {
+		h.Handler.Handle(ctx, reply, req)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d)
+	var replied atomic.Bool
+	once := func(ctx context.Context, result any, err error) error {
+		if !replied.CompareAndSwap(false, true) {
+			return ErrAlreadyReplied
+		}
+		defer cancel()
+		return reply(ctx, result, err)
+	}
+	context.AfterFunc(ctx, func() {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			once(ctx, nil, Errorf(RequestFailed, "%s timed out after %v", req.Method, d))
+		}
+	})
+
+	h.Handler.Handle(ctx, once, req)
+
+	// A detached request keeps its deadline until it is answered.
+	if st, ok := ctx.Value(requestStateKey{}).(*requestState); !ok || !st.detached.Load() {
+		cancel()
+	}
+}
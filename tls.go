@@ -0,0 +1,57 @@
+package golsptoolkit
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
+)
+
+// NewServerTLSConfig returns a TLS configuration for serving LSP sessions
+// with the certificate in certFile and key in keyFile.
+//
+// If clientCAFile is non-empty, client certificates are verified against the
+// CAs it contains. With requireClientCert set, clients that present no
+// certificate are rejected; otherwise a certificate is only verified when
+// offered.
+func NewServerTLSConfig(certFile, keyFile, clientCAFile string, requireClientCert bool) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("golsptoolkit: no certificates found in " + clientCAFile)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+		if requireClientCert {
+			config.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	} else if requireClientCert {
+		return nil, errors.New("golsptoolkit: client certificates required but no client CA file given")
+	}
+	return config, nil
+}
+
+// DialTLS connects to the LSP peer listening on the TCP address addr over
+// TLS. A nil config uses the defaults of crypto/tls.
+func DialTLS(ctx context.Context, addr string, config *tls.Config) (*StreamConn, error) {
+	d := tls.Dialer{Config: config}
+	nc, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return NewNetConn(nc), nil
+}
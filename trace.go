@@ -0,0 +1,89 @@
+package golsptoolkit
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// TraceValue represents the level of verbosity with which the server
+// reports its execution trace.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#traceValue
+type TraceValue = string
+
+const (
+	TraceValueOff      TraceValue = "off"
+	TraceValueMessages TraceValue = "messages"
+	TraceValueVerbose  TraceValue = "verbose"
+)
+
+// Methods of tracing.
+const (
+	MethodSetTrace = "$/setTrace"
+	MethodLogTrace = "$/logTrace"
+)
+
+// SetTraceParams are the parameters of the $/setTrace notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#setTrace
+type SetTraceParams struct {
+	// Value is the new trace level.
+	Value TraceValue `json:"value"`
+}
+
+// LogTraceParams are the parameters of the $/logTrace notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#logTrace
+type LogTraceParams struct {
+	// Message is the message to log.
+	Message string `json:"message"`
+
+	// Verbose is additional information, only sent if the trace level is
+	// verbose.
+	Verbose string `json:"verbose,omitempty"`
+}
+
+// SetTrace sets the trace level of the connection, typically to the Trace
+// of InitializeParams. Later $/setTrace notifications from the peer update
+// it as well; they are not passed to the handler.
+func (c *JSONRPCConn) SetTrace(value TraceValue) {
+	c.mu.Lock()
+	c.trace = value
+	c.mu.Unlock()
+}
+
+// Trace returns the trace level of the connection. It is TraceValueOff
+// until set.
+func (c *JSONRPCConn) Trace() TraceValue {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.trace == "" {
+		return TraceValueOff
+	}
+	return c.trace
+}
+
+// LogTrace sends a $/logTrace notification with message, according to the
+// trace level of the connection: it does nothing if the level is off, and
+// sends verbose only if the level is verbose.
+func (c *JSONRPCConn) LogTrace(ctx context.Context, message, verbose string) error {
+	params := &LogTraceParams{Message: message}
+	switch c.Trace() {
+	case TraceValueOff:
+		return nil
+	case TraceValueVerbose:
+		params.Verbose = verbose
+	}
+	return c.Notify(ctx, MethodLogTrace, params)
+}
+
+// setPeerTrace updates the trace level from the params of a $/setTrace
+// notification.
+func (c *JSONRPCConn) setPeerTrace(raw json.RawMessage) {
+	params, err := decodeParams[SetTraceParams](raw)
+	if err != nil {
+		c.logf("invalid %s: %v", MethodSetTrace, err)
+		return
+	}
+	c.SetTrace(params.Value)
+}
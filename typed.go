@@ -0,0 +1,75 @@
+package golsptoolkit
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Validator is implemented by parameter types that can check their own
+// contents after decoding. Typed handlers answer requests whose parameters
+// fail validation with InvalidParams.
+type Validator interface {
+	Validate() error
+}
+
+// Handle registers fn on mux as the handler for the request method. The
+// request parameters are decoded into In and validated; malformed or invalid
+// parameters are answered with InvalidParams without calling fn. The value
+// returned by fn is sent as the result, or its error as the response error.
+func Handle[In, Out any](mux *Mux, method string, fn func(ctx context.Context, params In) (Out, error)) {
+	mux.RegisterFunc(method, func(ctx context.Context, reply Replier, req *Request) {
+		params, err := decodeParams[In](req.Params)
+		if err != nil {
+			reply(ctx, nil, err)
+			return
+		}
+		result, err := fn(ctx, params)
+		if err != nil {
+			reply(ctx, nil, err)
+			return
+		}
+		reply(ctx, result, nil)
+	})
+}
+
+// HandleNotification registers fn on mux as the handler for the notification
+// method. Notifications cannot be answered, so decoding errors and errors
+// returned by fn are only reported through the connection's error log.
+func HandleNotification[In any](mux *Mux, method string, fn func(ctx context.Context, params In) error) {
+	mux.RegisterFunc(method, func(ctx context.Context, reply Replier, req *Request) {
+		params, err := decodeParams[In](req.Params)
+		if err == nil {
+			err = fn(ctx, params)
+		}
+		reply(ctx, nil, err)
+	})
+}
+
+// Call sends a request over conn and waits for its result, decoded into
+// Out. It is the typed counterpart of JSONRPCConn.Call:
+//
+//	items, err := golsptoolkit.Call[[]golsptoolkit.LSPAny](ctx, conn, "workspace/configuration", params)
+func Call[Out any](ctx context.Context, conn *JSONRPCConn, method string, params any) (Out, error) {
+	var result Out
+	err := conn.Call(ctx, method, params, &result)
+	return result, err
+}
+
+// decodeParams decodes raw request parameters into T and validates them.
+// Absent parameters decode as JSON null.
+func decodeParams[T any](raw json.RawMessage) (T, error) {
+	var params T
+	if len(raw) == 0 {
+		raw = json.RawMessage("null")
+	}
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return params, &ResponseError{Code: InvalidParams, Message: err.Error()}
+	}
+
+	if v, ok := any(&params).(Validator); ok {
+		if err := v.Validate(); err != nil {
+			return params, &ResponseError{Code: InvalidParams, Message: err.Error()}
+		}
+	}
+	return params, nil
+}
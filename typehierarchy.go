@@ -0,0 +1,96 @@
+package golsptoolkit
+
+// Methods of type hierarchy.
+const (
+	MethodTextDocumentPrepareTypeHierarchy = "textDocument/prepareTypeHierarchy"
+	MethodTypeHierarchySupertypes          = "typeHierarchy/supertypes"
+	MethodTypeHierarchySubtypes            = "typeHierarchy/subtypes"
+)
+
+// TypeHierarchyOptions represents the type hierarchy options a server
+// announces in ServerCapabilities.TypeHierarchyProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#typeHierarchyOptions
+type TypeHierarchyOptions struct {
+	WorkDoneProgressOptions
+}
+
+// TypeHierarchyRegistrationOptions are the options of a dynamic or static
+// registration of type hierarchy.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#typeHierarchyRegistrationOptions
+type TypeHierarchyRegistrationOptions struct {
+	TextDocumentRegistrationOptions
+	TypeHierarchyOptions
+	StaticRegistrationOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (TypeHierarchyRegistrationOptions) RegistrationMethod() string {
+	return MethodTextDocumentPrepareTypeHierarchy
+}
+
+// TypeHierarchyPrepareParams are the parameters of the
+// textDocument/prepareTypeHierarchy request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#typeHierarchyPrepareParams
+type TypeHierarchyPrepareParams struct {
+	TextDocumentPositionParams
+	WorkDoneProgressParams
+}
+
+// TypeHierarchyItem represents a type, such as a class or an interface, in
+// the type hierarchy.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#typeHierarchyItem
+type TypeHierarchyItem struct {
+	// Name is the name of the item.
+	Name string `json:"name"`
+
+	// Kind is the kind of the item.
+	Kind SymbolKind `json:"kind"`
+
+	// Tags tweak the rendering of the item.
+	Tags []SymbolTag `json:"tags,omitempty"`
+
+	// Detail is more detail for the item, such as a package path.
+	Detail string `json:"detail,omitempty"`
+
+	// URI is the document of the item.
+	URI DocumentURI `json:"uri"`
+
+	// Range encloses the item, including leading and trailing comments.
+	Range Range `json:"range"`
+
+	// SelectionRange is selected and revealed when the item is picked,
+	// such as the name of a type. It must be contained in Range.
+	SelectionRange Range `json:"selectionRange"`
+
+	// Data is preserved by the client between a prepareTypeHierarchy
+	// request and the supertypes and subtypes requests.
+	Data LSPAny `json:"data,omitempty"`
+}
+
+// TypeHierarchySupertypesParams are the parameters of the
+// typeHierarchy/supertypes request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#typeHierarchySupertypesParams
+type TypeHierarchySupertypesParams struct {
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// Item is the item to find the supertypes of.
+	Item TypeHierarchyItem `json:"item"`
+}
+
+// TypeHierarchySubtypesParams are the parameters of the
+// typeHierarchy/subtypes request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#typeHierarchySubtypesParams
+type TypeHierarchySubtypesParams struct {
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// Item is the item to find the subtypes of.
+	Item TypeHierarchyItem `json:"item"`
+}
@@ -0,0 +1,118 @@
+package golsptoolkit
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// decodeMessage decodes an incoming message and checks it against the
+// JSON-RPC 2.0 rules: the jsonrpc member is "2.0", a request or notification
+// has a non-empty method and structured params, an id is an integer or a
+// string, and a response carries exactly one of result and error.
+//
+// Violations are reported as a ParseError or InvalidRequest error, together
+// with as much of the message as could be decoded so the caller can address
+// its answer. A malformed response is instead returned as a response
+// carrying the error, so that the call waiting for it fails rather than
+// hangs.
+//
+// The message comes from a pool; release it with releaseWireMessage once it
+// has been acted on.
+func decodeMessage(body []byte) (*wireMessage, *ResponseError) {
+	fields := acquireMessageFields()
+	defer releaseMessageFields(fields)
+
+	msg := acquireWireMessage()
+	if err := json.Unmarshal(body, &fields); err != nil {
+		if json.Valid(body) {
+			return msg, NewError(InvalidRequest, "message is not a JSON object")
+		}
+		return msg, NewError(ParseError, err.Error())
+	}
+
+	rawID, hasID := fields["id"]
+	msg.hasID = hasID
+	if hasID && !isJSONNull(rawID) {
+		var id ID
+		if err := json.Unmarshal(rawID, &id); err != nil {
+			return msg, NewError(InvalidRequest, "id must be an integer or a string")
+		}
+		msg.ID = &id
+	}
+
+	rawMethod, hasMethod := fields["method"]
+	if hasMethod {
+		if json.Unmarshal(rawMethod, &msg.Method) != nil || msg.Method == "" {
+			msg.Method = ""
+			return msg, NewError(InvalidRequest, "method must be a non-empty string")
+		}
+	}
+
+	if json.Unmarshal(fields["jsonrpc"], &msg.JSONRPC) != nil || msg.JSONRPC != JSONRPCVersion {
+		return invalid(msg, hasMethod, `jsonrpc must be "2.0"`)
+	}
+
+	rawResult, hasResult := fields["result"]
+	rawError, hasError := fields["error"]
+	if hasMethod {
+		if hasID && msg.ID == nil {
+			return msg, NewError(InvalidRequest, "request id must not be null")
+		}
+		if hasResult || hasError {
+			return msg, NewError(InvalidRequest, "request must not carry result or error")
+		}
+		if raw, ok := fields["params"]; ok && !isJSONNull(raw) {
+			if !isJSONStructured(raw) {
+				return msg, NewError(InvalidRequest, "params must be an object or an array")
+			}
+			msg.Params = raw
+		}
+		return msg, nil
+	}
+
+	switch {
+	case !hasID:
+		return msg, NewError(InvalidRequest, "message has neither method nor id")
+	case hasResult && hasError:
+		return invalid(msg, false, "response must not carry both result and error")
+	case hasError:
+		var rerr struct {
+			Code    *Integer        `json:"code"`
+			Message *string         `json:"message"`
+			Data    json.RawMessage `json:"data"`
+		}
+		if json.Unmarshal(rawError, &rerr) != nil || rerr.Code == nil || rerr.Message == nil {
+			return invalid(msg, false, "response error must have an integer code and a string message")
+		}
+		msg.Error = &ResponseError{Code: *rerr.Code, Message: *rerr.Message}
+		if rerr.Data != nil && !isJSONNull(rerr.Data) {
+			msg.Error.Data = rerr.Data
+		}
+	case hasResult:
+		msg.Result = rawResult
+	default:
+		return invalid(msg, false, "response must carry result or error")
+	}
+	return msg, nil
+}
+
+// invalid reports an InvalidRequest violation of msg. For responses the
+// error is carried by the response itself.
+func invalid(msg *wireMessage, request bool, reason string) (*wireMessage, *ResponseError) {
+	rerr := NewError(InvalidRequest, reason)
+	if request || msg.ID == nil {
+		return msg, rerr
+	}
+	msg.Result = nil
+	msg.Error = Errorf(InvalidRequest, "malformed response: %s", reason)
+	return msg, nil
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+}
+
+func isJSONStructured(raw json.RawMessage) bool {
+	raw = bytes.TrimSpace(raw)
+	return len(raw) > 0 && (raw[0] == '{' || raw[0] == '[')
+}
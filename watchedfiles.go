@@ -0,0 +1,105 @@
+package golsptoolkit
+
+// Methods of watched files.
+const (
+	MethodWorkspaceDidChangeWatchedFiles = "workspace/didChangeWatchedFiles"
+)
+
+// DidChangeWatchedFilesRegistrationOptions are the options of a dynamic
+// registration of workspace/didChangeWatchedFiles notifications.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#didChangeWatchedFilesRegistrationOptions
+type DidChangeWatchedFilesRegistrationOptions struct {
+	// Watchers are the watchers to register.
+	Watchers []FileSystemWatcher `json:"watchers"`
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (DidChangeWatchedFilesRegistrationOptions) RegistrationMethod() string {
+	return MethodWorkspaceDidChangeWatchedFiles
+}
+
+// FileSystemWatcher represents files the client watches on behalf of the
+// server.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#fileSystemWatcher
+type FileSystemWatcher struct {
+	// GlobPattern is the glob pattern to watch. It may only be a
+	// RelativePattern if the client announced relativePatternSupport.
+	GlobPattern Or2[string, RelativePattern] `json:"globPattern"`
+
+	// Kind is the kinds of events to watch for. If zero, it is
+	// WatchKindCreate | WatchKindChange | WatchKindDelete.
+	Kind WatchKind `json:"kind,omitempty"`
+}
+
+// RelativePattern is a glob pattern matched relatively to a base URI.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#relativePattern
+type RelativePattern struct {
+	// BaseURI is the workspace folder or the URI the pattern is relative
+	// to.
+	BaseURI Or2[WorkspaceFolder, URI] `json:"baseUri"`
+
+	// Pattern is the glob pattern.
+	Pattern string `json:"pattern"`
+}
+
+// WatchKind is a bit set of the kinds of file events to watch for.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#watchKind
+type WatchKind = UInteger
+
+const (
+	WatchKindCreate WatchKind = 1
+	WatchKindChange WatchKind = 2
+	WatchKindDelete WatchKind = 4
+)
+
+// DidChangeWatchedFilesParams are the parameters of the
+// workspace/didChangeWatchedFiles notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#didChangeWatchedFilesParams
+type DidChangeWatchedFilesParams struct {
+	Changes []FileEvent `json:"changes"`
+}
+
+// FileEvent represents a change of a watched file.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#fileEvent
+type FileEvent struct {
+	URI  DocumentURI    `json:"uri"`
+	Type FileChangeType `json:"type"`
+}
+
+// FileChangeType represents the kind of change of a watched file.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#fileChangeType
+type FileChangeType UInteger
+
+const (
+	FileChangeTypeCreated FileChangeType = 1
+	FileChangeTypeChanged FileChangeType = 2
+	FileChangeTypeDeleted FileChangeType = 3
+)
+
+var fileChangeTypeNames = enumNames{"FileChangeType", []string{
+	FileChangeTypeCreated: "Created",
+	FileChangeTypeChanged: "Changed",
+	FileChangeTypeDeleted: "Deleted",
+}}
+
+// String returns the name of t, such as "Created".
+func (t FileChangeType) String() string {
+	return fileChangeTypeNames.name(int64(t))
+}
+
+// IsValid reports whether t is one of the defined file change types.
+func (t FileChangeType) IsValid() bool {
+	return fileChangeTypeNames.valid(int64(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Undefined values are rejected.
+func (t *FileChangeType) UnmarshalJSON(data []byte) error {
+	return decodeEnum(data, t, fileChangeTypeNames)
+}
@@ -0,0 +1,522 @@
+package golsptoolkit
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// websocketGUID is the key suffix defined by RFC 6455 for computing
+// Sec-WebSocket-Accept.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// WebSocket frame opcodes.
+const (
+	wsContinuation byte = 0x0
+	wsText         byte = 0x1
+	wsBinary       byte = 0x2
+	wsClose        byte = 0x8
+	wsPing         byte = 0x9
+	wsPong         byte = 0xA
+)
+
+// DefaultMaxWebSocketMessage is the default bound, in bytes, on the payload
+// of an incoming WebSocket message.
+const DefaultMaxWebSocketMessage = 64 << 20
+
+// wsReadChunk is the largest part of a frame payload allocated before its
+// bytes arrive.
+const wsReadChunk = 64 << 10
+
+// maxWebSocketControl bounds the payload of a control frame, as RFC 6455
+// section 5.5 requires.
+const maxWebSocketControl = 125
+
+// WebSocket close status codes sent when the peer violates the protocol.
+const (
+	wsCloseProtocolError uint16 = 1002
+	wsCloseTooBig        uint16 = 1009
+)
+
+// wsCloseTimeout bounds how long Close tries to send the close frame.
+const wsCloseTimeout = time.Second
+
+// Errors returned by the WebSocket transport.
+var (
+	ErrNotWebSocket      = errors.New("golsptoolkit: request is not a websocket upgrade")
+	ErrWebSocketProtocol = errors.New("golsptoolkit: websocket protocol violation")
+)
+
+// errWebSocketTooBig reports a message exceeding MaxMessageSize.
+var errWebSocketTooBig = fmt.Errorf("%w: message too large", ErrWebSocketProtocol)
+
+// WebSocketConn carries LSP messages over a WebSocket, one JSON-RPC message
+// per WebSocket message, as done by vscode-ws-jsonrpc and the Monaco and
+// Theia language clients. No base protocol headers are sent on the wire.
+//
+// Reads must not be issued concurrently; writes may be.
+type WebSocketConn struct {
+	// MaxMessageSize bounds the payload of an incoming message, summed over
+	// its fragments. A larger message fails the connection with close
+	// status 1009. Zero means DefaultMaxWebSocketMessage.
+	MaxMessageSize int
+
+	nc     net.Conn
+	br     *bufio.Reader
+	client bool
+
+	reader contextReader
+	wmu    sync.Mutex
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// UpgradeWebSocket performs the server side of the WebSocket handshake and
+// takes over the underlying connection of w.
+func UpgradeWebSocket(w http.ResponseWriter, r *http.Request) (*WebSocketConn, error) {
+	if r.Method != http.MethodGet ||
+		!headerContainsToken(r.Header, "Connection", "upgrade") ||
+		!headerContainsToken(r.Header, "Upgrade", "websocket") {
+		http.Error(w, "websocket upgrade required", http.StatusUpgradeRequired)
+		return nil, ErrNotWebSocket
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "unsupported websocket version", http.StatusBadRequest)
+		return nil, ErrNotWebSocket
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		http.Error(w, "missing Sec-WebSocket-Key", http.StatusBadRequest)
+		return nil, ErrNotWebSocket
+	}
+
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket not supported", http.StatusInternalServerError)
+		return nil, errors.New("golsptoolkit: response writer does not support hijacking")
+	}
+	nc, rw, err := hj.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + websocketAccept(key) + "\r\n\r\n"
+	if _, err := rw.WriteString(resp); err != nil {
+		nc.Close()
+		return nil, err
+	}
+	if err := rw.Flush(); err != nil {
+		nc.Close()
+		return nil, err
+	}
+
+	return newWebSocketConn(nc, rw.Reader, false), nil
+}
+
+// WebSocketHandler returns an http.Handler that upgrades each request to a
+// WebSocket and calls handler with the resulting connection. The connection
+// is closed when handler returns.
+func WebSocketHandler(handler func(conn Conn)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := UpgradeWebSocket(w, r)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		handler(conn)
+	})
+}
+
+// ListenAndServeWebSocket listens on the TCP address addr and serves LSP
+// sessions over WebSockets with handler. If config is non-nil the server only
+// accepts TLS connections (wss).
+func ListenAndServeWebSocket(addr string, config *tls.Config, handler func(conn Conn)) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	if config != nil {
+		l = tls.NewListener(l, config)
+	}
+	srv := &http.Server{Handler: WebSocketHandler(handler)}
+	return srv.Serve(l)
+}
+
+// WebSocketDialer holds options for connecting to a WebSocket endpoint.
+type WebSocketDialer struct {
+	// Header holds extra headers sent with the handshake request.
+	Header http.Header
+
+	// TLSConfig is used for wss endpoints. A nil config uses the defaults of
+	// crypto/tls with the URL host as server name.
+	TLSConfig *tls.Config
+}
+
+// DialWebSocket connects to the WebSocket endpoint at rawURL, which must use
+// the ws or wss scheme. Extra handshake headers may be supplied in header.
+func DialWebSocket(ctx context.Context, rawURL string, header http.Header) (*WebSocketConn, error) {
+	d := &WebSocketDialer{Header: header}
+	return d.Dial(ctx, rawURL)
+}
+
+// Dial connects to the WebSocket endpoint at rawURL, which must use the ws or
+// wss scheme.
+func (d *WebSocketDialer) Dial(ctx context.Context, rawURL string) (*WebSocketConn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var secure bool
+	switch u.Scheme {
+	case "ws":
+	case "wss":
+		secure = true
+	default:
+		return nil, fmt.Errorf("golsptoolkit: unsupported websocket scheme %q", u.Scheme)
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		if secure {
+			host = net.JoinHostPort(u.Hostname(), "443")
+		} else {
+			host = net.JoinHostPort(u.Hostname(), "80")
+		}
+	}
+
+	var nc net.Conn
+	if secure {
+		config := d.TLSConfig.Clone()
+		if config == nil {
+			config = &tls.Config{}
+		}
+		if config.ServerName == "" {
+			config.ServerName = u.Hostname()
+		}
+		td := tls.Dialer{Config: config}
+		nc, err = td.DialContext(ctx, "tcp", host)
+	} else {
+		var nd net.Dialer
+		nc, err = nd.DialContext(ctx, "tcp", host)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := websocketHandshake(ctx, nc, u, d.Header)
+	if err != nil {
+		nc.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+func websocketHandshake(ctx context.Context, nc net.Conn, u *url.URL, header http.Header) (*WebSocketConn, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		nc.SetDeadline(deadline)
+		defer nc.SetDeadline(time.Time{})
+	}
+
+	var nonce [16]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce[:])
+
+	req := &http.Request{
+		Method:     http.MethodGet,
+		URL:        u,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Host:       u.Host,
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	req.Header.Set("Sec-WebSocket-Version", "13")
+
+	if err := req.Write(nc); err != nil {
+		return nil, err
+	}
+
+	br := bufio.NewReader(nc)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return nil, fmt.Errorf("golsptoolkit: websocket handshake failed: %s", resp.Status)
+	}
+	if resp.Header.Get("Sec-WebSocket-Accept") != websocketAccept(key) {
+		return nil, fmt.Errorf("%w: invalid Sec-WebSocket-Accept", ErrWebSocketProtocol)
+	}
+
+	return newWebSocketConn(nc, br, true), nil
+}
+
+func newWebSocketConn(nc net.Conn, br *bufio.Reader, client bool) *WebSocketConn {
+	c := &WebSocketConn{nc: nc, br: br, client: client}
+	c.reader.read = c.readMessage
+	return c
+}
+
+// ReadMessage reads the next complete WebSocket message. Control frames are
+// handled transparently. It returns io.EOF once the peer closes the socket.
+func (c *WebSocketConn) ReadMessage(ctx context.Context) ([]byte, error) {
+	return c.reader.ReadMessage(ctx)
+}
+
+// readMessage reads the next message. A protocol violation by the peer
+// fails the connection with the matching close status.
+func (c *WebSocketConn) readMessage() ([]byte, error) {
+	msg, err := c.readFrames()
+	if errors.Is(err, ErrWebSocketProtocol) {
+		code := wsCloseProtocolError
+		if errors.Is(err, errWebSocketTooBig) {
+			code = wsCloseTooBig
+		}
+		c.closeWith(code)
+	}
+	return msg, err
+}
+
+func (c *WebSocketConn) readFrames() ([]byte, error) {
+	var msg []byte
+	started := false
+	for {
+		fin, op, payload, err := c.readFrame(len(msg))
+		if err != nil {
+			return nil, err
+		}
+
+		switch op {
+		case wsPing:
+			if err := c.writeFrame(context.Background(), wsPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case wsPong:
+			continue
+		case wsClose:
+			c.Close()
+			return nil, io.EOF
+		case wsText, wsBinary:
+			if started {
+				return nil, fmt.Errorf("%w: new message inside fragmented message", ErrWebSocketProtocol)
+			}
+			started = true
+			msg = payload
+		case wsContinuation:
+			if !started {
+				return nil, fmt.Errorf("%w: unexpected continuation frame", ErrWebSocketProtocol)
+			}
+			msg = append(msg, payload...)
+		default:
+			return nil, fmt.Errorf("%w: unknown opcode %#x", ErrWebSocketProtocol, op)
+		}
+
+		if fin {
+			return msg, nil
+		}
+	}
+}
+
+// WriteMessage writes body as a single text message, or as a binary message
+// if body is not valid UTF-8, as with compressed bodies.
+func (c *WebSocketConn) WriteMessage(ctx context.Context, body []byte) error {
+	if !utf8.Valid(body) {
+		return c.writeFrame(ctx, wsBinary, body)
+	}
+	return c.writeFrame(ctx, wsText, body)
+}
+
+// Close sends a close frame and closes the underlying connection. It is safe
+// to call more than once. If the close frame cannot be sent in time, for
+// example because a write to a stalled peer holds the connection, the
+// connection is closed without it.
+func (c *WebSocketConn) Close() error {
+	return c.closeWith(0)
+}
+
+// closeWith is Close sending the status code in the close frame, or no
+// status if code is zero.
+func (c *WebSocketConn) closeWith(code uint16) error {
+	c.closeOnce.Do(func() {
+		var payload []byte
+		if code != 0 {
+			payload = binary.BigEndian.AppendUint16(nil, code)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), wsCloseTimeout)
+		defer cancel()
+		sent := make(chan struct{})
+		go func() {
+			defer close(sent)
+			c.writeFrame(ctx, wsClose, payload)
+		}()
+		select {
+		case <-sent:
+		case <-ctx.Done():
+		}
+		// Closing the connection also unblocks a write still holding it.
+		c.closeErr = c.nc.Close()
+	})
+	return c.closeErr
+}
+
+// readFrame reads the next frame of a message of which buffered bytes have
+// been read already.
+func (c *WebSocketConn) readFrame(buffered int) (fin bool, op byte, payload []byte, err error) {
+	var hdr [2]byte
+	if _, err := io.ReadFull(c.br, hdr[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin = hdr[0]&0x80 != 0
+	op = hdr[0] & 0x0f
+	masked := hdr[1]&0x80 != 0
+
+	if masked == c.client {
+		return false, 0, nil, fmt.Errorf("%w: unexpected frame masking", ErrWebSocketProtocol)
+	}
+
+	n := uint64(hdr[1] & 0x7f)
+	switch n {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		n = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		n = binary.BigEndian.Uint64(ext[:])
+	}
+	if op&0x8 != 0 {
+		if !fin || n > maxWebSocketControl {
+			return false, 0, nil, fmt.Errorf("%w: invalid control frame", ErrWebSocketProtocol)
+		}
+	} else if limit := c.maxMessageSize(); n > uint64(limit-buffered) {
+		return false, 0, nil, fmt.Errorf("%w: %d bytes", errWebSocketTooBig, uint64(buffered)+n)
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.br, mask[:]); err != nil {
+			return false, 0, nil, err
+		}
+	}
+
+	// The payload is allocated as it arrives, so that a declared length
+	// alone does not commit memory.
+	for len(payload) < int(n) {
+		m := min(int(n)-len(payload), wsReadChunk)
+		payload = slices.Grow(payload, m)
+		if _, err := io.ReadFull(c.br, payload[len(payload):len(payload)+m]); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return false, 0, nil, err
+		}
+		payload = payload[:len(payload)+m]
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return fin, op, payload, nil
+}
+
+func (c *WebSocketConn) maxMessageSize() int {
+	if c.MaxMessageSize > 0 {
+		return c.MaxMessageSize
+	}
+	return DefaultMaxWebSocketMessage
+}
+
+func (c *WebSocketConn) writeFrame(ctx context.Context, op byte, payload []byte) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	frame := make([]byte, 0, 14+len(payload))
+	frame = append(frame, 0x80|op)
+
+	var maskBit byte
+	if c.client {
+		maskBit = 0x80
+	}
+	switch n := len(payload); {
+	case n < 126:
+		frame = append(frame, maskBit|byte(n))
+	case n <= 0xffff:
+		frame = append(frame, maskBit|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(n))
+	default:
+		frame = append(frame, maskBit|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(n))
+	}
+
+	if c.client {
+		var mask [4]byte
+		if _, err := rand.Read(mask[:]); err != nil {
+			return err
+		}
+		frame = append(frame, mask[:]...)
+		start := len(frame)
+		frame = append(frame, payload...)
+		for i := range frame[start:] {
+			frame[start+i] ^= mask[i%4]
+		}
+	} else {
+		frame = append(frame, payload...)
+	}
+
+	return withWriteDeadline(ctx, c.nc, func() error {
+		_, err := c.nc.Write(frame)
+		return err
+	})
+}
+
+func websocketAccept(key string) string {
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func headerContainsToken(h http.Header, name, token string) bool {
+	for _, v := range h.Values(name) {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
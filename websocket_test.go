@@ -0,0 +1,87 @@
+package golsptoolkit
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestWebSocketMessageTooBig(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	conn := newWebSocketConn(server, bufio.NewReader(server), false)
+	conn.MaxMessageSize = 1024
+	defer conn.Close()
+
+	// A masked text frame declaring a 1 TiB payload, none of which is sent:
+	// the frame must be rejected from its header alone.
+	frame := []byte{0x81, 0x80 | 127}
+	frame = binary.BigEndian.AppendUint64(frame, 1<<40)
+	go func() {
+		client.Write(frame)
+		io.Copy(io.Discard, client)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := conn.ReadMessage(ctx); !errors.Is(err, errWebSocketTooBig) {
+		t.Fatalf("ReadMessage = %v, want %v", err, errWebSocketTooBig)
+	}
+}
+
+func TestWebSocketFragmentsTooBig(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	conn := newWebSocketConn(server, bufio.NewReader(server), false)
+	conn.MaxMessageSize = 8
+	defer conn.Close()
+
+	// Two fragments of 5 bytes each, unmasked with a zero mask.
+	var frames []byte
+	frames = append(frames, 0x01, 0x80|5, 0, 0, 0, 0)
+	frames = append(frames, "hello"...)
+	frames = append(frames, 0x80, 0x80|5, 0, 0, 0, 0)
+	frames = append(frames, "world"...)
+	go func() {
+		client.Write(frames)
+		io.Copy(io.Discard, client)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := conn.ReadMessage(ctx); !errors.Is(err, errWebSocketTooBig) {
+		t.Fatalf("ReadMessage = %v, want %v", err, errWebSocketTooBig)
+	}
+}
+
+func TestWebSocketReadChunked(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	conn := newWebSocketConn(server, bufio.NewReader(server), false)
+	defer conn.Close()
+
+	body := make([]byte, 3*wsReadChunk+7)
+	for i := range body {
+		body[i] = 'a' + byte(i%26)
+	}
+	frame := []byte{0x81, 0x80 | 127}
+	frame = binary.BigEndian.AppendUint64(frame, uint64(len(body)))
+	frame = append(frame, 0, 0, 0, 0)
+	frame = append(frame, body...)
+	go func() {
+		client.Write(frame)
+		io.Copy(io.Discard, client)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	got, err := conn.ReadMessage(ctx)
+	if err != nil || string(got) != string(body) {
+		t.Fatalf("ReadMessage = %d bytes, %v, want %d bytes", len(got), err, len(body))
+	}
+}
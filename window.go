@@ -0,0 +1,164 @@
+package golsptoolkit
+
+import "context"
+
+// Methods of the window.
+const (
+	MethodWindowShowMessage        = "window/showMessage"
+	MethodWindowShowMessageRequest = "window/showMessageRequest"
+	MethodWindowLogMessage         = "window/logMessage"
+	MethodWindowShowDocument       = "window/showDocument"
+)
+
+// MessageType represents the severity of a message shown or logged by the
+// client.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#messageType
+type MessageType Integer
+
+const (
+	MessageTypeError   MessageType = 1
+	MessageTypeWarning MessageType = 2
+	MessageTypeInfo    MessageType = 3
+	MessageTypeLog     MessageType = 4
+)
+
+var messageTypeNames = enumNames{"MessageType", []string{
+	MessageTypeError:   "Error",
+	MessageTypeWarning: "Warning",
+	MessageTypeInfo:    "Info",
+	MessageTypeLog:     "Log",
+}}
+
+// String returns the name of t, such as "Error".
+func (t MessageType) String() string {
+	return messageTypeNames.name(int64(t))
+}
+
+// IsValid reports whether t is one of the defined message types.
+func (t MessageType) IsValid() bool {
+	return messageTypeNames.valid(int64(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Undefined values are rejected.
+func (t *MessageType) UnmarshalJSON(data []byte) error {
+	return decodeEnum(data, t, messageTypeNames)
+}
+
+// ShowMessageParams are the parameters of the window/showMessage
+// notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#showMessageParams
+type ShowMessageParams struct {
+	Type    MessageType `json:"type"`
+	Message string      `json:"message"`
+}
+
+// ShowMessageRequestParams are the parameters of the
+// window/showMessageRequest request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#showMessageRequestParams
+type ShowMessageRequestParams struct {
+	Type    MessageType `json:"type"`
+	Message string      `json:"message"`
+
+	// Actions are the actions offered to the user.
+	Actions []MessageActionItem `json:"actions,omitempty"`
+}
+
+// MessageActionItem represents an action offered by a
+// window/showMessageRequest request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#messageActionItem
+type MessageActionItem struct {
+	// Title is a short title, such as "Retry".
+	Title string `json:"title"`
+}
+
+// LogMessageParams are the parameters of the window/logMessage
+// notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#logMessageParams
+type LogMessageParams struct {
+	Type    MessageType `json:"type"`
+	Message string      `json:"message"`
+}
+
+// ShowMessage asks the client to display message with the
+// window/showMessage notification.
+func (c *JSONRPCConn) ShowMessage(ctx context.Context, typ MessageType, message string) error {
+	return c.Notify(ctx, MethodWindowShowMessage, &ShowMessageParams{Type: typ, Message: message})
+}
+
+// ShowInfo asks the client to display an informational message.
+func (c *JSONRPCConn) ShowInfo(ctx context.Context, message string) error {
+	return c.ShowMessage(ctx, MessageTypeInfo, message)
+}
+
+// ShowWarning asks the client to display a warning.
+func (c *JSONRPCConn) ShowWarning(ctx context.Context, message string) error {
+	return c.ShowMessage(ctx, MessageTypeWarning, message)
+}
+
+// ShowError asks the client to display an error.
+func (c *JSONRPCConn) ShowError(ctx context.Context, message string) error {
+	return c.ShowMessage(ctx, MessageTypeError, message)
+}
+
+// ShowMessageRequest asks the client to display message with actions and
+// waits for the user's choice. It returns nil if the user dismissed the
+// message without choosing an action.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#window_showMessageRequest
+func (c *JSONRPCConn) ShowMessageRequest(ctx context.Context, typ MessageType, message string, actions ...MessageActionItem) (*MessageActionItem, error) {
+	return Call[*MessageActionItem](ctx, c, MethodWindowShowMessageRequest, &ShowMessageRequestParams{Type: typ, Message: message, Actions: actions})
+}
+
+// Log asks the client to log message with the window/logMessage
+// notification.
+func (c *JSONRPCConn) Log(ctx context.Context, typ MessageType, message string) error {
+	return c.Notify(ctx, MethodWindowLogMessage, &LogMessageParams{Type: typ, Message: message})
+}
+
+// ShowDocumentParams are the parameters of the window/showDocument
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#showDocumentParams
+type ShowDocumentParams struct {
+	// URI is the URI of the document to show.
+	URI URI `json:"uri"`
+
+	// External shows the resource in an external program, such as a web
+	// browser for http URIs.
+	External bool `json:"external,omitempty"`
+
+	// TakeFocus gives the editor showing the resource focus. Clients may
+	// ignore it for external programs.
+	TakeFocus bool `json:"takeFocus,omitempty"`
+
+	// Selection is an optional range selected in the document, if it is
+	// a text file.
+	Selection *Range `json:"selection,omitempty"`
+}
+
+// ShowDocumentResult is the result of the window/showDocument request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#showDocumentResult
+type ShowDocumentResult struct {
+	// Success reports whether the document was shown.
+	Success bool `json:"success"`
+}
+
+// ShowDocument asks the client to show the document described by params
+// with the window/showDocument request, and reports whether it was shown.
+// It does nothing and reports false if caps, the client's capabilities, do
+// not announce support for the request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#window_showDocument
+func ShowDocument(ctx context.Context, conn *JSONRPCConn, caps *ClientCapabilities, params *ShowDocumentParams) (bool, error) {
+	if caps == nil || caps.Window == nil || caps.Window.ShowDocument == nil || !caps.Window.ShowDocument.Support {
+		return false, nil
+	}
+	result, err := Call[ShowDocumentResult](ctx, conn, MethodWindowShowDocument, params)
+	return result.Success, err
+}
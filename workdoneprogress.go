@@ -0,0 +1,183 @@
+package golsptoolkit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// WorkDoneProgressParams is embedded in the parameters of requests that
+// accept a work done progress token from the client.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workDoneProgressParams
+type WorkDoneProgressParams struct {
+	// WorkDoneToken is an optional token that a server can use to report
+	// work done progress.
+	WorkDoneToken *ProgressToken `json:"workDoneToken,omitempty"`
+}
+
+// WorkDoneProgressOptions is embedded in the server options of features that
+// can report work done progress.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workDoneProgressOptions
+type WorkDoneProgressOptions struct {
+	// WorkDoneProgress reports whether the server reports work done
+	// progress for requests of the feature.
+	WorkDoneProgress bool `json:"workDoneProgress,omitempty"`
+}
+
+// PartialResultParams is embedded in the parameters of requests that support
+// streaming partial results.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#partialResultParams
+type PartialResultParams struct {
+	// PartialResultToken is an optional token that a server can use to
+	// report partial results (e.g. streaming) to the client.
+	PartialResultToken *ProgressToken `json:"partialResultToken,omitempty"`
+}
+
+// Methods of work done progress.
+const (
+	MethodWorkDoneProgressCreate = "window/workDoneProgress/create"
+	MethodWorkDoneProgressCancel = "window/workDoneProgress/cancel"
+)
+
+// Values of the kind member of work done progress notifications.
+const (
+	WorkDoneProgressKindBegin  = "begin"
+	WorkDoneProgressKindReport = "report"
+	WorkDoneProgressKindEnd    = "end"
+)
+
+// WorkDoneProgressBegin represents the payload that starts a progress
+// report.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workDoneProgressBegin
+type WorkDoneProgressBegin struct {
+	// Kind is always "begin".
+	Kind string `json:"kind"`
+
+	// Title is a mandatory, brief description of the operation, such as
+	// "Indexing".
+	Title string `json:"title"`
+
+	// Cancellable controls whether a cancel button is shown, allowing the
+	// user to cancel the operation.
+	Cancellable bool `json:"cancellable,omitempty"`
+
+	// Message is optional, more detailed progress information, such as
+	// "3/25 files".
+	Message string `json:"message,omitempty"`
+
+	// Percentage is the optional progress to display, from 0 to 100.
+	Percentage *UInteger `json:"percentage,omitempty"`
+}
+
+// WorkDoneProgressReport represents the payload reporting progress on an
+// operation that has begun.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workDoneProgressReport
+type WorkDoneProgressReport struct {
+	// Kind is always "report".
+	Kind string `json:"kind"`
+
+	// Cancellable controls enablement state of a cancel button. It may
+	// only be set if the begin payload was cancellable.
+	Cancellable bool `json:"cancellable,omitempty"`
+
+	// Message is optional, more detailed progress information. If unset,
+	// the previous message, if any, is still valid.
+	Message string `json:"message,omitempty"`
+
+	// Percentage is the optional progress to display, from 0 to 100.
+	Percentage *UInteger `json:"percentage,omitempty"`
+}
+
+// WorkDoneProgressEnd represents the payload signaling the end of an
+// operation.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workDoneProgressEnd
+type WorkDoneProgressEnd struct {
+	// Kind is always "end".
+	Kind string `json:"kind"`
+
+	// Message is an optional final message, for example the outcome of
+	// the operation.
+	Message string `json:"message,omitempty"`
+}
+
+// WorkDoneProgressValue is the value of a $/progress notification reporting
+// work done progress: exactly one of its fields is set. It decodes
+// ProgressParams values into the payload named by their kind, which an Or3
+// would not consult: a report would also decode as a begin payload.
+type WorkDoneProgressValue struct {
+	Begin  *WorkDoneProgressBegin
+	Report *WorkDoneProgressReport
+	End    *WorkDoneProgressEnd
+}
+
+// errWorkDoneProgressValue is returned when a WorkDoneProgressValue has no
+// field set.
+var errWorkDoneProgressValue = errors.New("golsptoolkit: work done progress value has no value set")
+
+// MarshalJSON implements json.Marshaler. The kind of the payload is filled
+// in.
+func (v WorkDoneProgressValue) MarshalJSON() ([]byte, error) {
+	switch {
+	case v.Begin != nil:
+		p := *v.Begin
+		p.Kind = WorkDoneProgressKindBegin
+		return json.Marshal(p)
+	case v.Report != nil:
+		p := *v.Report
+		p.Kind = WorkDoneProgressKindReport
+		return json.Marshal(p)
+	case v.End != nil:
+		p := *v.End
+		p.Kind = WorkDoneProgressKindEnd
+		return json.Marshal(p)
+	}
+	return nil, errWorkDoneProgressValue
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The payload is told apart by
+// its kind property.
+func (v *WorkDoneProgressValue) UnmarshalJSON(data []byte) error {
+	var probe struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+	*v = WorkDoneProgressValue{}
+	switch probe.Kind {
+	case WorkDoneProgressKindBegin:
+		v.Begin = new(WorkDoneProgressBegin)
+		return json.Unmarshal(data, v.Begin)
+	case WorkDoneProgressKindReport:
+		v.Report = new(WorkDoneProgressReport)
+		return json.Unmarshal(data, v.Report)
+	case WorkDoneProgressKindEnd:
+		v.End = new(WorkDoneProgressEnd)
+		return json.Unmarshal(data, v.End)
+	}
+	return fmt.Errorf("golsptoolkit: unknown work done progress kind %q", probe.Kind)
+}
+
+// WorkDoneProgressCreateParams are the parameters of the
+// window/workDoneProgress/create request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#window_workDoneProgress_create
+type WorkDoneProgressCreateParams struct {
+	// Token is the token to be used to report progress.
+	Token ProgressToken `json:"token"`
+}
+
+// WorkDoneProgressCancelParams are the parameters of the
+// window/workDoneProgress/cancel notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#window_workDoneProgress_cancel
+type WorkDoneProgressCancelParams struct {
+	// Token is the token of the progress to cancel.
+	Token ProgressToken `json:"token"`
+}
@@ -0,0 +1,399 @@
+package golsptoolkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Methods of workspace edits.
+const (
+	MethodWorkspaceApplyEdit = "workspace/applyEdit"
+)
+
+// ResourceOperationKind represents a kind of resource operation a client
+// can apply as part of a workspace edit.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#resourceOperationKind
+type ResourceOperationKind = string
+
+const (
+	ResourceOperationKindCreate ResourceOperationKind = "create"
+	ResourceOperationKindRename ResourceOperationKind = "rename"
+	ResourceOperationKindDelete ResourceOperationKind = "delete"
+)
+
+// FailureHandlingKind represents how a client handles the failure of a
+// workspace edit.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#failureHandlingKind
+type FailureHandlingKind = string
+
+const (
+	// FailureHandlingKindAbort applies none of the edit if any part of it
+	// fails.
+	FailureHandlingKindAbort FailureHandlingKind = "abort"
+
+	// FailureHandlingKindTransactional applies all of the edit or, if any
+	// part fails, none of it.
+	FailureHandlingKindTransactional FailureHandlingKind = "transactional"
+
+	// FailureHandlingKindTextOnlyTransactional is transactional if the edit
+	// only changes text, and aborts otherwise.
+	FailureHandlingKindTextOnlyTransactional FailureHandlingKind = "textOnlyTransactional"
+
+	// FailureHandlingKindUndo undoes the parts of the edit already applied
+	// when a later part fails.
+	FailureHandlingKindUndo FailureHandlingKind = "undo"
+)
+
+// WorkspaceEdit represents changes to many resources managed in the
+// workspace. Use NewWorkspaceEdit to encode changes in the form the client
+// supports.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspaceEdit
+type WorkspaceEdit struct {
+	// Changes holds the edits to existing documents, by URI.
+	Changes map[DocumentURI][]TextEdit `json:"changes,omitempty"`
+
+	// DocumentChanges holds versioned document edits and, if the client
+	// supports them, resource operations. Clients that support it use it
+	// instead of Changes.
+	DocumentChanges []DocumentChange `json:"documentChanges,omitempty"`
+
+	// ChangeAnnotations describes the annotations referenced by the edits,
+	// by identifier.
+	ChangeAnnotations map[ChangeAnnotationIdentifier]ChangeAnnotation `json:"changeAnnotations,omitempty"`
+}
+
+// ChangeAnnotationIdentifier identifies a ChangeAnnotation in a
+// WorkspaceEdit.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#changeAnnotationIdentifier
+type ChangeAnnotationIdentifier = string
+
+// ChangeAnnotation describes a group of changes of a workspace edit.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#changeAnnotation
+type ChangeAnnotation struct {
+	// Label is a human-readable string shown prominently in the user
+	// interface.
+	Label string `json:"label"`
+
+	// NeedsConfirmation reports whether the user must confirm the changes
+	// before they are applied.
+	NeedsConfirmation bool `json:"needsConfirmation,omitempty"`
+
+	// Description is a human-readable string shown less prominently.
+	Description string `json:"description,omitempty"`
+}
+
+// AnnotatedTextEdit is a TextEdit with an optional change annotation. With
+// an empty AnnotationID, it is encoded as a plain TextEdit.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#annotatedTextEdit
+type AnnotatedTextEdit struct {
+	TextEdit
+
+	// AnnotationID identifies the annotation of the edit.
+	AnnotationID ChangeAnnotationIdentifier `json:"annotationId,omitempty"`
+}
+
+// TextDocumentEdit represents edits to a single text document, at an
+// optional version.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocumentEdit
+type TextDocumentEdit struct {
+	// TextDocument is the document to change.
+	TextDocument OptionalVersionedTextDocumentIdentifier `json:"textDocument"`
+
+	// Edits are the edits to apply.
+	Edits []AnnotatedTextEdit `json:"edits"`
+}
+
+// MarshalJSON implements json.Marshaler. A nil Edits is sent as an empty
+// array.
+func (e TextDocumentEdit) MarshalJSON() ([]byte, error) {
+	type textDocumentEdit TextDocumentEdit
+	if e.Edits == nil {
+		e.Edits = []AnnotatedTextEdit{}
+	}
+	return json.Marshal(textDocumentEdit(e))
+}
+
+// CreateFile is a resource operation that creates a file.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#createFile
+type CreateFile struct {
+	// Kind is always "create".
+	Kind ResourceOperationKind `json:"kind"`
+
+	// URI is the resource to create.
+	URI DocumentURI `json:"uri"`
+
+	Options *CreateFileOptions `json:"options,omitempty"`
+
+	// AnnotationID identifies the annotation of the operation.
+	AnnotationID ChangeAnnotationIdentifier `json:"annotationId,omitempty"`
+}
+
+// CreateFileOptions are the options of a CreateFile operation.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#createFileOptions
+type CreateFileOptions struct {
+	// Overwrite overwrites an existing file. It wins over IgnoreIfExists.
+	Overwrite bool `json:"overwrite,omitempty"`
+
+	// IgnoreIfExists does nothing if the file exists.
+	IgnoreIfExists bool `json:"ignoreIfExists,omitempty"`
+}
+
+// RenameFile is a resource operation that renames a file or folder.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#renameFile
+type RenameFile struct {
+	// Kind is always "rename".
+	Kind ResourceOperationKind `json:"kind"`
+
+	OldURI DocumentURI `json:"oldUri"`
+	NewURI DocumentURI `json:"newUri"`
+
+	Options *RenameFileOptions `json:"options,omitempty"`
+
+	// AnnotationID identifies the annotation of the operation.
+	AnnotationID ChangeAnnotationIdentifier `json:"annotationId,omitempty"`
+}
+
+// RenameFileOptions are the options of a RenameFile operation.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#renameFileOptions
+type RenameFileOptions struct {
+	// Overwrite overwrites the target if it exists. It wins over
+	// IgnoreIfExists.
+	Overwrite bool `json:"overwrite,omitempty"`
+
+	// IgnoreIfExists does nothing if the target exists.
+	IgnoreIfExists bool `json:"ignoreIfExists,omitempty"`
+}
+
+// DeleteFile is a resource operation that deletes a file or folder.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#deleteFile
+type DeleteFile struct {
+	// Kind is always "delete".
+	Kind ResourceOperationKind `json:"kind"`
+
+	// URI is the file or folder to delete.
+	URI DocumentURI `json:"uri"`
+
+	Options *DeleteFileOptions `json:"options,omitempty"`
+
+	// AnnotationID identifies the annotation of the operation.
+	AnnotationID ChangeAnnotationIdentifier `json:"annotationId,omitempty"`
+}
+
+// DeleteFileOptions are the options of a DeleteFile operation.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#deleteFileOptions
+type DeleteFileOptions struct {
+	// Recursive deletes the content of folders recursively.
+	Recursive bool `json:"recursive,omitempty"`
+
+	// IgnoreIfNotExists does nothing if the file does not exist.
+	IgnoreIfNotExists bool `json:"ignoreIfNotExists,omitempty"`
+}
+
+// DocumentChange is an entry of WorkspaceEdit.DocumentChanges: exactly one
+// of its fields is set. When encoding a resource operation, its Kind is
+// filled in if empty. Its four variants, told apart by kind, are one more
+// than Or3 holds.
+type DocumentChange struct {
+	TextDocumentEdit *TextDocumentEdit
+	CreateFile       *CreateFile
+	RenameFile       *RenameFile
+	DeleteFile       *DeleteFile
+}
+
+// errDocumentChange is returned when a DocumentChange has no field set.
+var errDocumentChange = errors.New("golsptoolkit: document change has no value set")
+
+// MarshalJSON implements json.Marshaler.
+func (c DocumentChange) MarshalJSON() ([]byte, error) {
+	switch {
+	case c.TextDocumentEdit != nil:
+		return json.Marshal(c.TextDocumentEdit)
+	case c.CreateFile != nil:
+		op := *c.CreateFile
+		op.Kind = ResourceOperationKindCreate
+		return json.Marshal(op)
+	case c.RenameFile != nil:
+		op := *c.RenameFile
+		op.Kind = ResourceOperationKindRename
+		return json.Marshal(op)
+	case c.DeleteFile != nil:
+		op := *c.DeleteFile
+		op.Kind = ResourceOperationKindDelete
+		return json.Marshal(op)
+	}
+	return nil, errDocumentChange
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The variant is told apart by
+// the kind property; a value without one is a text document edit.
+func (c *DocumentChange) UnmarshalJSON(data []byte) error {
+	var probe struct {
+		Kind ResourceOperationKind `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+	*c = DocumentChange{}
+	switch probe.Kind {
+	case "":
+		c.TextDocumentEdit = new(TextDocumentEdit)
+		return json.Unmarshal(data, c.TextDocumentEdit)
+	case ResourceOperationKindCreate:
+		c.CreateFile = new(CreateFile)
+		return json.Unmarshal(data, c.CreateFile)
+	case ResourceOperationKindRename:
+		c.RenameFile = new(RenameFile)
+		return json.Unmarshal(data, c.RenameFile)
+	case ResourceOperationKindDelete:
+		c.DeleteFile = new(DeleteFile)
+		return json.Unmarshal(data, c.DeleteFile)
+	}
+	return fmt.Errorf("golsptoolkit: unknown resource operation kind %q", probe.Kind)
+}
+
+// kind returns the resource operation kind of c, or "" for a text document
+// edit.
+func (c DocumentChange) kind() ResourceOperationKind {
+	switch {
+	case c.CreateFile != nil:
+		return ResourceOperationKindCreate
+	case c.RenameFile != nil:
+		return ResourceOperationKindRename
+	case c.DeleteFile != nil:
+		return ResourceOperationKindDelete
+	}
+	return ""
+}
+
+// NewWorkspaceEdit encodes changes as a WorkspaceEdit in the form the client
+// supports, according to caps, which may be nil:
+//
+//   - if the client supports documentChanges, changes are sent as is in
+//     DocumentChanges;
+//   - otherwise, text document edits are merged by URI into Changes,
+//     dropping document versions.
+//
+// It returns an error if changes include a resource operation the client
+// does not support. If the client does not support change annotations, the
+// annotation identifiers of the edits are cleared and annotations is not
+// sent.
+func NewWorkspaceEdit(changes []DocumentChange, annotations map[ChangeAnnotationIdentifier]ChangeAnnotation, caps *ClientCapabilities) (*WorkspaceEdit, error) {
+	var editCaps WorkspaceEditClientCapabilities
+	if caps != nil && caps.Workspace != nil && caps.Workspace.WorkspaceEdit != nil {
+		editCaps = *caps.Workspace.WorkspaceEdit
+	}
+
+	for _, c := range changes {
+		if kind := c.kind(); kind != "" && (!editCaps.DocumentChanges || !slices.Contains(editCaps.ResourceOperations, kind)) {
+			return nil, fmt.Errorf("golsptoolkit: client does not support %q resource operations", kind)
+		}
+	}
+
+	annotated := editCaps.ChangeAnnotationSupport != nil
+	if !annotated {
+		changes = withoutAnnotations(changes)
+		annotations = nil
+	}
+
+	edit := &WorkspaceEdit{ChangeAnnotations: annotations}
+	if editCaps.DocumentChanges {
+		edit.DocumentChanges = changes
+		return edit, nil
+	}
+	edit.Changes = make(map[DocumentURI][]TextEdit)
+	for _, c := range changes {
+		if c.TextDocumentEdit == nil {
+			continue
+		}
+		uri := c.TextDocumentEdit.TextDocument.URI
+		for _, e := range c.TextDocumentEdit.Edits {
+			edit.Changes[uri] = append(edit.Changes[uri], e.TextEdit)
+		}
+	}
+	return edit, nil
+}
+
+// withoutAnnotations returns a copy of changes with all annotation
+// identifiers cleared.
+func withoutAnnotations(changes []DocumentChange) []DocumentChange {
+	out := make([]DocumentChange, len(changes))
+	for i, c := range changes {
+		switch {
+		case c.TextDocumentEdit != nil:
+			e := *c.TextDocumentEdit
+			e.Edits = slices.Clone(e.Edits)
+			for j := range e.Edits {
+				e.Edits[j].AnnotationID = ""
+			}
+			c.TextDocumentEdit = &e
+		case c.CreateFile != nil:
+			op := *c.CreateFile
+			op.AnnotationID = ""
+			c.CreateFile = &op
+		case c.RenameFile != nil:
+			op := *c.RenameFile
+			op.AnnotationID = ""
+			c.RenameFile = &op
+		case c.DeleteFile != nil:
+			op := *c.DeleteFile
+			op.AnnotationID = ""
+			c.DeleteFile = &op
+		}
+		out[i] = c
+	}
+	return out
+}
+
+// ApplyWorkspaceEditParams are the parameters of the workspace/applyEdit
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#applyWorkspaceEditParams
+type ApplyWorkspaceEditParams struct {
+	// Label is an optional label for the edit, for example shown in the
+	// undo stack.
+	Label string `json:"label,omitempty"`
+
+	// Edit is the edit to apply.
+	Edit WorkspaceEdit `json:"edit"`
+}
+
+// ApplyWorkspaceEditResult is the result of the workspace/applyEdit
+// request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#applyWorkspaceEditResult
+type ApplyWorkspaceEditResult struct {
+	// Applied reports whether the edit was applied.
+	Applied bool `json:"applied"`
+
+	// FailureReason is an optional textual description of why the edit
+	// was not applied.
+	FailureReason string `json:"failureReason,omitempty"`
+
+	// FailedChange is the index in DocumentChanges of the change that
+	// failed, if the client signals failureHandling.
+	FailedChange *UInteger `json:"failedChange,omitempty"`
+}
+
+// ApplyWorkspaceEdit asks the client to apply edit with the
+// workspace/applyEdit request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspace_applyEdit
+func ApplyWorkspaceEdit(ctx context.Context, conn *JSONRPCConn, label string, edit WorkspaceEdit) (ApplyWorkspaceEditResult, error) {
+	return Call[ApplyWorkspaceEditResult](ctx, conn, MethodWorkspaceApplyEdit, &ApplyWorkspaceEditParams{Label: label, Edit: edit})
+}
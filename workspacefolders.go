@@ -0,0 +1,70 @@
+package golsptoolkit
+
+import "slices"
+
+// Methods of workspace folders.
+const (
+	MethodWorkspaceWorkspaceFolders          = "workspace/workspaceFolders"
+	MethodWorkspaceDidChangeWorkspaceFolders = "workspace/didChangeWorkspaceFolders"
+)
+
+// WorkspaceFolder represents a workspace folder open in the client.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspaceFolder
+type WorkspaceFolder struct {
+	// URI is the URI of the folder.
+	URI URI `json:"uri"`
+
+	// Name is the name of the folder, used to refer to it in the user
+	// interface.
+	Name string `json:"name"`
+}
+
+// WorkspaceFoldersServerCapabilities represents the workspace folder
+// capabilities a server announces in ServerCapabilities.Workspace.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspaceFoldersServerCapabilities
+type WorkspaceFoldersServerCapabilities struct {
+	// Supported reports whether the server supports workspace folders.
+	Supported bool `json:"supported,omitempty"`
+
+	// ChangeNotifications asks the client to send
+	// workspace/didChangeWorkspaceFolders notifications: a string used as
+	// the id to unregister the notifications with, or true.
+	ChangeNotifications Or2[string, bool] `json:"changeNotifications,omitzero"`
+}
+
+// DidChangeWorkspaceFoldersParams are the parameters of the
+// workspace/didChangeWorkspaceFolders notification.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#didChangeWorkspaceFoldersParams
+type DidChangeWorkspaceFoldersParams struct {
+	// Event describes the change.
+	Event WorkspaceFoldersChangeEvent `json:"event"`
+}
+
+// WorkspaceFoldersChangeEvent describes a change to the workspace folders.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspaceFoldersChangeEvent
+type WorkspaceFoldersChangeEvent struct {
+	// Added are the folders that were added.
+	Added []WorkspaceFolder `json:"added"`
+
+	// Removed are the folders that were removed.
+	Removed []WorkspaceFolder `json:"removed"`
+}
+
+// Apply returns folders with the change applied: folders whose URI is in
+// Removed are dropped, and Added folders not yet present are appended.
+// folders is not modified.
+func (e WorkspaceFoldersChangeEvent) Apply(folders []WorkspaceFolder) []WorkspaceFolder {
+	out := slices.DeleteFunc(slices.Clone(folders), func(f WorkspaceFolder) bool {
+		return slices.ContainsFunc(e.Removed, func(r WorkspaceFolder) bool { return r.URI == f.URI })
+	})
+	for _, a := range e.Added {
+		if !slices.ContainsFunc(out, func(f WorkspaceFolder) bool { return f.URI == a.URI }) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
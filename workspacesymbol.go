@@ -0,0 +1,90 @@
+package golsptoolkit
+
+// Methods of workspace symbols.
+const (
+	MethodWorkspaceSymbol        = "workspace/symbol"
+	MethodWorkspaceSymbolResolve = "workspaceSymbol/resolve"
+)
+
+// WorkspaceSymbolOptions represents the workspace symbol options a server
+// announces in ServerCapabilities.WorkspaceSymbolProvider.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspaceSymbolOptions
+type WorkspaceSymbolOptions struct {
+	WorkDoneProgressOptions
+
+	// ResolveProvider reports whether the server resolves the location
+	// of workspace symbols with workspaceSymbol/resolve.
+	ResolveProvider bool `json:"resolveProvider,omitempty"`
+}
+
+// WorkspaceSymbolRegistrationOptions are the options of a dynamic registration of
+// workspace symbols.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspaceSymbolRegistrationOptions
+type WorkspaceSymbolRegistrationOptions struct {
+	WorkspaceSymbolOptions
+}
+
+// RegistrationMethod implements RegistrationOptions.
+func (WorkspaceSymbolRegistrationOptions) RegistrationMethod() string {
+	return MethodWorkspaceSymbol
+}
+
+// WorkspaceSymbolParams are the parameters of the workspace/symbol request.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspaceSymbolParams
+type WorkspaceSymbolParams struct {
+	WorkDoneProgressParams
+	PartialResultParams
+
+	// Query filters the symbols. An empty query asks for all symbols.
+	Query string `json:"query"`
+}
+
+// WorkspaceSymbol represents a symbol found by the workspace/symbol request.
+// Its range may be left for workspaceSymbol/resolve to compute.
+//
+// The workspace/symbol request may also answer with []SymbolInformation.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspaceSymbol
+type WorkspaceSymbol struct {
+	// Name is the name of the symbol.
+	Name string `json:"name"`
+
+	// Kind is the kind of the symbol.
+	Kind SymbolKind `json:"kind"`
+
+	// Tags tweak the rendering of the symbol.
+	Tags []SymbolTag `json:"tags,omitempty"`
+
+	// ContainerName is the name of the symbol containing the symbol, used
+	// as a qualifier in the user interface.
+	ContainerName string `json:"containerName,omitempty"`
+
+	// Location is the location of the symbol. Its range may be omitted if
+	// the client announced resolveSupport for the location.range
+	// property, and then computed by workspaceSymbol/resolve.
+	Location WorkspaceSymbolLocation `json:"location"`
+
+	// Data is preserved by the client between a workspace/symbol and a
+	// workspaceSymbol/resolve request.
+	Data LSPAny `json:"data,omitempty"`
+}
+
+// WorkspaceSymbolLocation is the location of a workspace symbol: a Location,
+// or only the URI of the document if Range is nil.
+//
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#workspaceSymbol
+type WorkspaceSymbolLocation struct {
+	URI   DocumentURI `json:"uri"`
+	Range *Range      `json:"range,omitempty"`
+}
+
+// Location returns l as a Location, and false if its range is not known yet.
+func (l WorkspaceSymbolLocation) Location() (Location, bool) {
+	if l.Range == nil {
+		return Location{URI: l.URI}, false
+	}
+	return Location{URI: l.URI, Range: *l.Range}, true
+}